@@ -0,0 +1,110 @@
+package errors
+
+// AggregatePolicy политика выбора ошибки, которая представляет цепочку ошибок.
+// Используется в GetErrType, HTTPStatusCode, GRPCStatusCode и Cast для Multierror.
+// * errs -- все *Error дерева ошибок в порядке обхода Walk, len(errs) > 0.
+// Должна вернуть одну из ошибок errs.
+type AggregatePolicy func(errs []error) error
+
+// DefaultAggregatePolicy политика по-умолчанию.
+// Для выбора ошибки с другой политикой без изменения глобального значения используйте Aggregate.
+var DefaultAggregatePolicy = AggregateFirst //nolint:gochecknoglobals
+
+// severityOrder типы ошибок в порядке убывания важности:
+// сначала ошибки сервера, затем ошибки клиента.
+var severityOrder = []IErrType{ //nolint:gochecknoglobals
+	Internal,
+	Unavailable,
+	DownstreamDependencyTimedout,
+	Unknown,
+	MaximumAttempts,
+	SubscriptionExpired,
+	Unauthenticated,
+	Unauthorized,
+	Duplicate,
+	NotFound,
+	Validation,
+	InputBody,
+	Empty,
+}
+
+// severityRank вернет позицию типа et в severityOrder.
+// Неизвестные типы менее важны любого из известных.
+func severityRank(et IErrType) int {
+	for i, t := range severityOrder {
+		if t == et {
+			return i
+		}
+	}
+	return len(severityOrder)
+}
+
+// AggregateFirst выберет первую ошибку.
+func AggregateFirst(errs []error) error {
+	return errs[0]
+}
+
+// AggregateLast выберет последнюю ошибку.
+func AggregateLast(errs []error) error {
+	return errs[len(errs)-1]
+}
+
+// AggregateMostSevere выберет самую важную ошибку: ошибки сервера важнее ошибок клиента.
+// Из ошибок одного типа выбирается первая.
+func AggregateMostSevere(errs []error) error {
+	return AggregatePriority(severityOrder...)(errs)
+}
+
+// AggregatePriority вернет политику, выбирающую ошибку по списку типов types:
+// будет выбрана первая ошибка с наиболее приоритетным типом.
+// Если ни один тип не подошел, будет выбрана первая ошибка.
+func AggregatePriority(types ...IErrType) AggregatePolicy {
+	return func(errs []error) error {
+		for _, t := range types {
+			for _, e := range errs {
+				if et, _ := GetErrType(e); et == t {
+					return e
+				}
+			}
+		}
+		return errs[0]
+	}
+}
+
+// Aggregate выберет с помощью политики policy ошибку (*Error), представляющую дерево err.
+// Если policy == nil, используется DefaultAggregatePolicy.
+// Если в дереве нет *Error, вернется nil и false.
+//
+// Тип, HTTP- и gRPC-статус цепочки с заданной политикой:
+//
+//	if e, ok := errors.Aggregate(err, errors.AggregateMostSevere); ok {
+//		status := e.ErrorType().HTTPStatusCode()
+//	}
+func Aggregate(err error, policy AggregatePolicy) (*Error, bool) {
+	var errs []error
+	Walk(err, func(e error, _ int) bool {
+		if _, ok := e.(*Error); ok { //nolint:errorlint
+			errs = append(errs, e)
+		}
+		return false
+	})
+	if len(errs) == 0 {
+		return nil, false
+	}
+
+	if policy == nil {
+		policy = DefaultAggregatePolicy
+	}
+	if policy == nil {
+		policy = AggregateFirst
+	}
+	e, ok := policy(errs).(*Error) //nolint:errorlint
+	return e, ok
+}
+
+// aggregate выберет с помощью DefaultAggregatePolicy ошибку, представляющую дерево err.
+// Если в дереве нет *Error, вернется nil.
+func aggregate(err error) *Error {
+	e, _ := Aggregate(err, nil)
+	return e
+}
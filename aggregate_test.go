@@ -0,0 +1,100 @@
+package errors
+
+import (
+	origerrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAggregatePolicy(t *testing.T) {
+	eNotFound := NotFoundErr("not found")
+	eInternal := IternalErr("internal")
+	eValidation := ValidationErr("validation")
+
+	err := Combine(
+		origerrors.New("std"),
+		eNotFound,
+		CombineTree("save", eInternal, eValidation),
+	)
+
+	tests := []struct {
+		name   string
+		policy AggregatePolicy
+		want   *Error
+		status int
+		code   codes.Code
+	}{
+		{
+			name:   "first",
+			policy: AggregateFirst,
+			want:   eNotFound,
+			status: http.StatusNotFound,
+			code:   codes.NotFound,
+		},
+		{
+			name:   "last",
+			policy: AggregateLast,
+			want:   eValidation,
+			status: http.StatusUnprocessableEntity,
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "most severe",
+			policy: AggregateMostSevere,
+			want:   eInternal,
+			status: http.StatusInternalServerError,
+			code:   codes.Internal,
+		},
+		{
+			name:   "priority",
+			policy: AggregatePriority(Duplicate, Validation, NotFound),
+			want:   eValidation,
+			status: http.StatusUnprocessableEntity,
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "priority fallback",
+			policy: AggregatePriority(Duplicate),
+			want:   eNotFound,
+			status: http.StatusNotFound,
+			code:   codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e, ok := Aggregate(err, tt.policy)
+			require.True(t, ok)
+			require.Equal(t, tt.want, e)
+
+			status, ok := HTTPStatusCode(e)
+			require.True(t, ok)
+			require.Equal(t, tt.status, status)
+
+			code, ok := GRPCStatusCode(e)
+			require.True(t, ok)
+			require.Equal(t, tt.code, code)
+		})
+	}
+
+	// политика по-умолчанию: AggregateFirst
+	e, ok := Cast(err)
+	require.True(t, ok)
+	require.Equal(t, eNotFound, e)
+
+	et, ok := GetErrType(err)
+	require.True(t, ok)
+	require.Equal(t, NotFound, et)
+
+	_, ok = Aggregate(Combine(origerrors.New("1"), origerrors.New("2")), AggregateLast)
+	require.False(t, ok)
+
+	et, ok = GetErrType(Combine(origerrors.New("1"), origerrors.New("2")))
+	require.False(t, ok)
+	require.Equal(t, Unknown, et)
+}
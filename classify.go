@@ -0,0 +1,166 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	origerrors "errors"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// ClassifyRule правило классификации ошибки.
+// Вернет тип ошибки и true, если правило применимо к err.
+type ClassifyRule func(err error) (IErrType, bool)
+
+// DefaultClassifier классификатор по-умолчанию. Используется в Classify и GetErrType.
+var DefaultClassifier = NewClassifier() //nolint:gochecknoglobals
+
+// Classifier определяет IErrType для ошибок, не являющихся *Error,
+// по встроенным правилам для ошибок стандартной библиотеки и пользовательским правилам.
+type Classifier struct {
+	mu    sync.RWMutex
+	rules []ClassifyRule
+}
+
+// NewClassifier конструктор *Classifier.
+// * rules ...ClassifyRule -- пользовательские правила,
+// проверяются раньше встроенных в порядке указания.
+func NewClassifier(rules ...ClassifyRule) *Classifier {
+	all := make([]ClassifyRule, 0, len(rules)+len(stdClassifyRules))
+	all = append(all, rules...)
+	return &Classifier{
+		rules: append(all, stdClassifyRules...),
+	}
+}
+
+// Register добавит пользовательские правила.
+// Правила проверяются раньше встроенных и ранее добавленных.
+func (c *Classifier) Register(rules ...ClassifyRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rules = append(append([]ClassifyRule(nil), rules...), c.rules...)
+}
+
+// Classify вернет тип ошибки err.
+// Для *Error и Multierror тип определяется как в GetErrType.
+// Для прочих ошибок правила применяются к каждой ошибке цепочки (см. Walk):
+// вернется тип первой найденной *Error или тип по первому подходящему правилу.
+// Если ни одно правило не подошло, вернется Unknown и false.
+func (c *Classifier) Classify(err error) (IErrType, bool) {
+	if err == nil {
+		return defaultErrType, false
+	}
+	if et, ok := errTypeOf(err); ok {
+		return et, ok
+	}
+
+	c.mu.RLock()
+	rules := c.rules
+	c.mu.RUnlock()
+
+	var errType IErrType = defaultErrType
+	found := false
+	Walk(err, func(e error, _ int) bool {
+		if ee, ok := e.(*Error); ok { //nolint:errorlint
+			errType, found = errTypeOf(ee)
+			return true
+		}
+		for _, rule := range rules {
+			if et, ok := rule(e); ok {
+				errType, found = et, true
+				return true
+			}
+		}
+		return false
+	})
+
+	return errType, found
+}
+
+// Wrap обернет ошибку err в *Error с типом, определенным Classify.
+// Сообщение *Error -- err.Error(), err будет сохранена в цепочке.
+// * ops ...Options -- дополнительная параметризация *Error.
+// Для *Error вернется err без изменений, для err == nil -- nil.
+func (c *Classifier) Wrap(err error, ops ...Options) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*Error); ok { //nolint:errorlint
+		return err
+	}
+
+	et, _ := c.Classify(err)
+	op := make([]Options, 0, len(ops)+2)
+	op = append(op, SetMsg(err.Error()), SetErrorType(et))
+	op = append(op, ops...)
+	return Wrap(NewWith(op...), err)
+}
+
+// Classify вернет тип ошибки err с помощью DefaultClassifier.
+func Classify(err error) IErrType {
+	et, _ := DefaultClassifier.Classify(err)
+	return et
+}
+
+// NewClassified обернет ошибку err в типизированный *Error с помощью DefaultClassifier.
+// См. Classifier.Wrap.
+func NewClassified(err error, ops ...Options) error {
+	return DefaultClassifier.Wrap(err, ops...)
+}
+
+// встроенные правила
+
+var stdClassifyRules = []ClassifyRule{ //nolint:gochecknoglobals
+	classifyIs(context.DeadlineExceeded, DownstreamDependencyTimedout),
+	classifyIs(os.ErrNotExist, NotFound),
+	classifyIs(os.ErrPermission, Unauthorized),
+	classifyIs(io.ErrUnexpectedEOF, InputBody),
+	classifyIs(sql.ErrNoRows, NotFound),
+	classifyNet,
+	classifyJSON,
+}
+
+func classifyIs(target error, et IErrType) ClassifyRule {
+	return func(err error) (IErrType, bool) {
+		return et, isTarget(err, target)
+	}
+}
+
+func classifyNet(err error) (IErrType, bool) {
+	switch t := err.(type) { //nolint:errorlint
+	case *url.Error:
+		// прочие ошибки классифицируются по вложенной ошибке
+		if t.Timeout() {
+			return DownstreamDependencyTimedout, true
+		}
+
+	case *net.OpError:
+		if t.Timeout() {
+			return DownstreamDependencyTimedout, true
+		}
+		if origerrors.Is(t.Err, syscall.ECONNREFUSED) {
+			return Unavailable, true
+		}
+
+	case net.Error:
+		if t.Timeout() {
+			return DownstreamDependencyTimedout, true
+		}
+	}
+
+	return defaultErrType, false
+}
+
+func classifyJSON(err error) (IErrType, bool) {
+	switch err.(type) { //nolint:errorlint
+	case *json.SyntaxError, *json.UnmarshalTypeError:
+		return InputBody, true
+	}
+	return defaultErrType, false
+}
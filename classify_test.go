@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	origerrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	var jsonSyntaxErr error
+	{
+		var v interface{}
+		jsonSyntaxErr = json.Unmarshal([]byte("{"), &v)
+	}
+	var jsonTypeErr error
+	{
+		var v struct{ A int }
+		jsonTypeErr = json.Unmarshal([]byte(`{"A":"1"}`), &v)
+	}
+	_, notExistErr := os.Open("/not/exists/file")
+
+	connRefused := &net.OpError{
+		Op:  "dial",
+		Net: "tcp",
+		Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED},
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want IErrType
+		ok   bool
+	}{
+		{"nil", nil, Unknown, false},
+		{"unknown", origerrors.New("unknown"), Unknown, false},
+		{"*Error", NotFoundErr("not found"), NotFound, true},
+		{"wrapped *Error", fmt.Errorf("ctx: %w", ValidationErr("bad")), Validation, true},
+		{"canceled", context.Canceled, Unknown, false},
+		{"deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), DownstreamDependencyTimedout, true},
+		{"not exist", notExistErr, NotFound, true},
+		{"permission", fmt.Errorf("open: %w", os.ErrPermission), Unauthorized, true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, InputBody, true},
+		{"net timeout", os.ErrDeadlineExceeded, DownstreamDependencyTimedout, true},
+		{"conn refused", connRefused, Unavailable, true},
+		{"url", &url.Error{Op: "Get", URL: "http://localhost", Err: connRefused}, Unavailable, true},
+		{"url other", &url.Error{Op: "Get", URL: "http://localhost", Err: origerrors.New("bad scheme")}, Unknown, false},
+		{"url timeout", &url.Error{Op: "Get", URL: "http://localhost", Err: context.DeadlineExceeded}, DownstreamDependencyTimedout, true},
+		{"json syntax", jsonSyntaxErr, InputBody, true},
+		{"json type", jsonTypeErr, InputBody, true},
+		{"sql no rows", fmt.Errorf("query: %w", sql.ErrNoRows), NotFound, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			et, ok := DefaultClassifier.Classify(tt.err)
+			require.Equal(t, tt.want, et)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.want, Classify(tt.err))
+
+			et, ok = GetErrType(tt.err)
+			require.Equal(t, tt.want, et)
+			require.Equal(t, tt.ok, ok)
+		})
+	}
+
+	status, ok := HTTPStatusCode(sql.ErrNoRows)
+	require.True(t, ok)
+	require.Equal(t, http.StatusNotFound, status)
+
+	_, ok = HTTPStatusCode(context.Canceled)
+	require.False(t, ok)
+}
+
+func TestClassifierRules(t *testing.T) {
+	errCustom := origerrors.New("custom")
+
+	c := NewClassifier(func(err error) (IErrType, bool) {
+		return Duplicate, err == errCustom //nolint:errorlint
+	})
+	c.Register(func(err error) (IErrType, bool) {
+		return Empty, err == io.EOF //nolint:errorlint
+	})
+
+	require.Equal(t, Duplicate, typeOf(c.Classify(fmt.Errorf("ctx: %w", errCustom))))
+	require.Equal(t, Empty, typeOf(c.Classify(io.EOF)))
+	require.Equal(t, NotFound, typeOf(c.Classify(sql.ErrNoRows)))
+
+	// правила вызывающего не изменяются
+	rules := make([]ClassifyRule, 1, 1+len(stdClassifyRules))
+	rules[0] = func(error) (IErrType, bool) { return Empty, false }
+	_ = NewClassifier(rules...)
+	for _, r := range rules[1:cap(rules)] {
+		require.Nil(t, r)
+	}
+}
+
+func typeOf(et IErrType, _ bool) IErrType {
+	return et
+}
+
+func TestNewClassified(t *testing.T) {
+	require.Nil(t, NewClassified(nil))
+
+	typed := ValidationErr("bad")
+	require.Equal(t, typed, NewClassified(typed))
+
+	err := NewClassified(sql.ErrNoRows, SetOperation("storage.Get"))
+	e, ok := Cast(err)
+	require.True(t, ok)
+	require.Equal(t, NotFound, e.ErrorType())
+	require.Equal(t, "storage.Get", e.Operation())
+	require.Equal(t, sql.ErrNoRows.Error(), e.Msg())
+	require.True(t, ContainsByErr(err, sql.ErrNoRows))
+}
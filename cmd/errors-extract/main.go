@@ -0,0 +1,168 @@
+// Команда errors-extract найдет ID и сообщения ошибок в исходных кодах Go-модуля
+// и обновит файлы переводов active.<язык>.toml (см. пакет extract).
+//
+//	go run github.com/ovsinc/errors/cmd/errors-extract -dir . -out locales -source en -lang ru,de
+//
+// Для исходного языка тексты берутся из кода, в файлах остальных языков сохраняются
+// существующие переводы, новые, измененные и устаревшие сообщения помечаются комментариями.
+// Обновляются также все уже существующие в каталоге -out файлы active.*.toml.
+//
+// С флагом -check файлы не записываются, а команда завершится с кодом 1,
+// если файлы переводов не соответствуют исходным кодам или один ID сообщения
+// используется с разными текстами (например, для CI).
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ovsinc/errors"
+	"github.com/ovsinc/errors/extract"
+	"golang.org/x/text/language"
+)
+
+func main() {
+	var (
+		dir     = flag.String("dir", ".", "module root directory")
+		out     = flag.String("out", "locales", "directory of active.<lang>.toml files")
+		source  = flag.String("source", "en", "source language of messages in code")
+		langs   = flag.String("lang", "", "comma separated list of translation languages")
+		prune   = flag.Bool("prune", false, "remove obsolete messages")
+		tests   = flag.Bool("tests", false, "scan *_test.go files")
+		builtin = flag.Bool("builtin", false, "add built-in messages of the errors package")
+		check   = flag.Bool("check", false, "do not write files, exit with code 1 if they are out of date")
+	)
+	flag.Parse()
+
+	changed, err := run(*dir, *out, *source, *langs, *prune, *tests, *builtin, !*check)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "errors-extract:", err)
+		os.Exit(1)
+	}
+	if *check && changed {
+		os.Exit(1)
+	}
+}
+
+func run(dir, out, source, langs string, prune, tests, builtin, write bool) (bool, error) {
+	srcTag, err := language.Parse(source)
+	if err != nil {
+		return false, err
+	}
+
+	msgs, conflicts := extract.Scan(dir, tests)
+	if conflicts != nil {
+		if len(msgs) == 0 {
+			return false, conflicts
+		}
+		// конфликты сообщений не мешают обновлению файлов, но при проверке являются ошибкой
+		if write {
+			fmt.Fprintln(os.Stderr, "errors-extract:", conflicts)
+			conflicts = nil
+		}
+	}
+	if builtin {
+		msgs = addBuiltin(msgs)
+	}
+
+	tags, err := languages(out, srcTag, langs)
+	if err != nil {
+		return false, err
+	}
+
+	if write {
+		if err := os.MkdirAll(out, 0o755); err != nil { //nolint:gosec
+			return false, err
+		}
+	}
+
+	var changed bool
+	for _, tag := range tags {
+		path := filepath.Join(out, errors.MessageFilePrefix+tag.String()+".toml")
+
+		old, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return changed, err
+		}
+
+		data, report, err := extract.Merge(old, tag, srcTag, msgs, prune)
+		if err != nil {
+			return changed, fmt.Errorf("%s: %w", path, err)
+		}
+		if bytes.Equal(old, data) {
+			continue
+		}
+		changed = true
+
+		fmt.Fprintf(os.Stderr, "%s: new %d, changed %d, obsolete %d, removed %d\n",
+			path, len(report.New), len(report.Changed), len(report.Obsolete), len(report.Removed))
+		if write {
+			if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec
+				return changed, err
+			}
+		}
+	}
+
+	return changed, conflicts
+}
+
+// languages вернет исходный язык, языки langs и языки существующих файлов каталога out.
+func languages(out string, source language.Tag, langs string) ([]language.Tag, error) {
+	set := map[language.Tag]struct{}{source: {}}
+
+	for _, l := range strings.Split(langs, ",") {
+		if l = strings.TrimSpace(l); l == "" {
+			continue
+		}
+		tag, err := language.Parse(l)
+		if err != nil {
+			return nil, err
+		}
+		set[tag] = struct{}{}
+	}
+
+	files, err := filepath.Glob(filepath.Join(out, errors.MessageFilePrefix+"*.toml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		l := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f), errors.MessageFilePrefix), ".toml")
+		tag, err := language.Parse(l)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		set[tag] = struct{}{}
+	}
+
+	tags := make([]language.Tag, 0, len(set))
+	for tag := range set {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].String() < tags[j].String() })
+	return tags, nil
+}
+
+// addBuiltin добавит в msgs встроенные сообщения пакета errors (см. errors.DefaultMessages).
+// Тексты встроенных сообщений, найденных без текста, будут дополнены.
+func addBuiltin(msgs []*extract.Message) []*extract.Message {
+	byID := make(map[string]*extract.Message, len(msgs))
+	for _, m := range msgs {
+		byID[m.ID] = m
+	}
+	for _, m := range errors.DefaultMessages() {
+		prev, ok := byID[m.ID]
+		switch {
+		case !ok:
+			msgs = append(msgs, &extract.Message{Message: *m})
+		case prev.Other == "":
+			prev.Message = *m
+		}
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].ID < msgs[j].ID })
+	return msgs
+}
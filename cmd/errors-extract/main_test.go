@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ovsinc/errors"
+	"github.com/ovsinc/errors/extract"
+	"github.com/stretchr/testify/require"
+)
+
+func writeModule(t *testing.T, src string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.go"), []byte(src), 0o600))
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	dir := writeModule(t, `package app
+
+import "github.com/ovsinc/errors"
+
+var ErrHello = errors.NewWith(errors.SetID("ErrHello"), errors.SetMsg("hello"))
+`)
+	out := filepath.Join(dir, "locales")
+
+	// проверка: файлов нет
+	changed, err := run(dir, out, "en", "ru", false, false, false, false)
+	require.NoError(t, err)
+	require.True(t, changed)
+	_, err = os.Stat(out)
+	require.True(t, os.IsNotExist(err))
+
+	// запись
+	changed, err = run(dir, out, "en", "ru", false, false, false, true)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	en, err := os.ReadFile(filepath.Join(out, "active.en.toml"))
+	require.NoError(t, err)
+	require.Equal(t, "[ErrHello]\nother = \"hello\"\n", string(en))
+	ru, err := os.ReadFile(filepath.Join(out, "active.ru.toml"))
+	require.NoError(t, err)
+	require.Contains(t, string(ru), "[ErrHello]\n")
+
+	// проверка: файлы соответствуют исходным кодам
+	changed, err = run(dir, out, "en", "", false, false, false, false)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestRunConflict(t *testing.T) {
+	dir := writeModule(t, `package app
+
+import "github.com/ovsinc/errors"
+
+var (
+	ErrA = errors.NewWith(errors.SetID("ErrA"), errors.SetMsg("first"))
+	ErrB = errors.NewWith(errors.SetID("ErrA"), errors.SetMsg("second"))
+)
+`)
+	out := filepath.Join(dir, "locales")
+
+	// запись: конфликт не мешает обновлению файлов
+	changed, err := run(dir, out, "en", "", false, false, false, true)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	// проверка: конфликт является ошибкой, даже если файлы не изменились
+	changed, err = run(dir, out, "en", "", false, false, false, false)
+	require.True(t, errors.ContainsByID(err, extract.ErrMessageConflict.ID()))
+	require.False(t, changed)
+}
@@ -0,0 +1,272 @@
+package errors
+
+import (
+	origerrors "errors"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// Coverage отчет о покрытии ошибок переводами (см. CheckCoverage).
+type Coverage struct {
+	// Missing ID сообщений без перевода по языкам.
+	Missing map[language.Tag][]string
+	// Unused ID сообщений из файлов, не используемые ни одной из ошибок, по языкам.
+	Unused map[language.Tag][]string
+	// Placeholders шаблоны, параметры которых отсутствуют в данных ошибки.
+	Placeholders []PlaceholderMismatch
+}
+
+// PlaceholderMismatch параметры шаблона сообщения, отсутствующие в данных шаблона ошибки
+// (см. Error.TranslateContext).
+type PlaceholderMismatch struct {
+	Lang language.Tag
+	ID   string
+	// Missing параметры шаблона, которых нет в данных ошибки.
+	Missing []string
+}
+
+// OK сообщит, что проблем с переводами не найдено.
+func (c *Coverage) OK() bool {
+	return len(c.Missing) == 0 && len(c.Unused) == 0 && len(c.Placeholders) == 0
+}
+
+// CheckCoverage проверит покрытие ошибок used переводами bundle.
+// * bundle *i18n.Bundle -- bundle с сообщениями;
+// * files []*i18n.MessageFile -- файлы сообщений bundle (см. LoadReport.Files),
+// используются для поиска неиспользуемых сообщений и проверки параметров шаблонов;
+// если не заданы, эти проверки не выполняются;
+// * used ...*Error -- ошибки приложения (например, реестр sentinel-ошибок).
+// Ошибки без ID не проверяются.
+//
+// Для каждого языка bundle проверяется наличие перевода каждого ID.
+// Параметры шаблона сравниваются с ключами данных шаблона ошибки:
+// явно заданными (см. SetTemplateData) или из контекста CtxKV.
+func CheckCoverage(bundle *i18n.Bundle, files []*i18n.MessageFile, used ...*Error) *Coverage {
+	// ID -> ключи данных шаблона всех ошибок с этим ID
+	keys := make(map[string]map[string]struct{})
+	ids := make([]string, 0, len(used))
+	for _, e := range used {
+		id := e.ID()
+		if id == "" {
+			continue
+		}
+		if _, ok := keys[id]; !ok {
+			keys[id] = make(map[string]struct{})
+			ids = append(ids, id)
+		}
+		for k := range templateKeys(e) {
+			keys[id][k] = struct{}{}
+		}
+	}
+	sort.Strings(ids)
+
+	return checkCoverage(bundle, files, ids, keys)
+}
+
+// CheckCoverageIDs как и CheckCoverage проверит покрытие переводами bundle,
+// но используемые сообщения заданы списком ID (например, найденных в исходных кодах,
+// см. пакет extract). Пустые ID не проверяются.
+// Данные шаблонов для ID неизвестны, поэтому параметры шаблонов не проверяются.
+func CheckCoverageIDs(bundle *i18n.Bundle, files []*i18n.MessageFile, ids []string) *Coverage {
+	keys := make(map[string]map[string]struct{}, len(ids))
+	sorted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := keys[id]; ok || id == "" {
+			continue
+		}
+		keys[id] = nil
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	return checkCoverage(bundle, files, sorted, keys)
+}
+
+// checkCoverage проверит покрытие переводами bundle сообщений ids (упорядоченных).
+// keys -- ключи данных шаблона по ID; если для ID они nil, параметры шаблонов не проверяются.
+func checkCoverage(
+	bundle *i18n.Bundle, files []*i18n.MessageFile, ids []string, keys map[string]map[string]struct{},
+) *Coverage {
+	c := &Coverage{
+		Missing: make(map[language.Tag][]string),
+		Unused:  make(map[language.Tag][]string),
+	}
+
+	for _, tag := range bundle.LanguageTags() {
+		l := i18n.NewLocalizer(bundle, tag.String())
+		for _, id := range ids {
+			_, err := l.Localize(&i18n.LocalizeConfig{MessageID: id})
+			var notFound *i18n.MessageNotFoundErr
+			if origerrors.As(err, &notFound) {
+				c.Missing[tag] = append(c.Missing[tag], id)
+			}
+		}
+	}
+
+	for _, f := range files {
+		for _, msg := range f.Messages {
+			provided, ok := keys[msg.ID]
+			if !ok {
+				c.Unused[f.Tag] = append(c.Unused[f.Tag], msg.ID)
+				continue
+			}
+			if provided == nil {
+				continue
+			}
+
+			var missing []string
+			for _, p := range messagePlaceholders(msg) {
+				if _, ok := provided[p]; !ok {
+					missing = append(missing, p)
+				}
+			}
+			if len(missing) > 0 {
+				c.Placeholders = append(c.Placeholders, PlaceholderMismatch{
+					Lang:    f.Tag,
+					ID:      msg.ID,
+					Missing: missing,
+				})
+			}
+		}
+	}
+
+	for tag := range c.Unused {
+		sort.Strings(c.Unused[tag])
+	}
+	sort.Slice(c.Placeholders, func(i, j int) bool {
+		a, b := c.Placeholders[i], c.Placeholders[j]
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Lang.String() < b.Lang.String()
+	})
+
+	return c
+}
+
+// AssertCoverage тестовый помощник: проверит покрытие ошибок переводами (см. CheckCoverage)
+// и сообщит о каждой найденной проблеме через t.Errorf.
+//
+//	func TestTranslations(t *testing.T) {
+//		errors.AssertCoverage(t, bundle, report.Files, ErrNotFound, ErrDuplicate)
+//	}
+func AssertCoverage(
+	t interface {
+		Helper()
+		Errorf(format string, args ...interface{})
+	},
+	bundle *i18n.Bundle, files []*i18n.MessageFile, used ...*Error,
+) bool {
+	t.Helper()
+
+	c := CheckCoverage(bundle, files, used...)
+	for _, tag := range sortedTags(c.Missing) {
+		for _, id := range c.Missing[tag] {
+			t.Errorf("translation %q is missing for language %q", id, tag)
+		}
+	}
+	for _, tag := range sortedTags(c.Unused) {
+		for _, id := range c.Unused[tag] {
+			t.Errorf("message %q of language %q is not used", id, tag)
+		}
+	}
+	for _, p := range c.Placeholders {
+		t.Errorf("message %q of language %q uses placeholders %v not provided by the error", p.ID, p.Lang, p.Missing)
+	}
+
+	return c.OK()
+}
+
+// templateKeys вернет ключи данных шаблона перевода ошибки e.
+func templateKeys(e *Error) map[string]struct{} {
+	tctx := e.TranslateContext()
+	keys := make(map[string]struct{}, len(tctx.TemplateData)+1)
+	for k := range tctx.TemplateData {
+		keys[k] = struct{}{}
+	}
+	// go-i18n передаст PluralCount в шаблон, если данные шаблона не заданы
+	if tctx.PluralCount != nil && tctx.TemplateData == nil {
+		keys["PluralCount"] = struct{}{}
+	}
+	return keys
+}
+
+// messagePlaceholders вернет упорядоченные имена параметров ({{.Name}}) всех форм сообщения.
+func messagePlaceholders(msg *i18n.Message) []string {
+	set := make(map[string]struct{})
+	for _, text := range []string{msg.Zero, msg.One, msg.Two, msg.Few, msg.Many, msg.Other} {
+		if text == "" {
+			continue
+		}
+		tmpl, err := template.New(msg.ID).Delims(msg.LeftDelim, msg.RightDelim).Parse(text)
+		if err != nil || tmpl.Tree == nil {
+			continue
+		}
+		templateFields(tmpl.Tree.Root, set)
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// templateFields соберет в set имена полей верхнего уровня ({{.Name}}) узла шаблона.
+func templateFields(node parse.Node, set map[string]struct{}) { //nolint:cyclop
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			templateFields(c, set)
+		}
+	case *parse.ActionNode:
+		templateFields(n.Pipe, set)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			templateFields(cmd, set)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			templateFields(arg, set)
+		}
+	case *parse.FieldNode:
+		if len(n.Ident) > 0 {
+			set[n.Ident[0]] = struct{}{}
+		}
+	case *parse.IfNode:
+		templateFields(&n.BranchNode, set)
+	case *parse.RangeNode:
+		// внутри range и with точка указывает на другое значение
+		templateFields(n.Pipe, set)
+		templateFields(n.ElseList, set)
+	case *parse.WithNode:
+		templateFields(n.Pipe, set)
+		templateFields(n.ElseList, set)
+	case *parse.BranchNode:
+		templateFields(n.Pipe, set)
+		templateFields(n.List, set)
+		templateFields(n.ElseList, set)
+	}
+}
+
+func sortedTags(m map[language.Tag][]string) []language.Tag {
+	tags := make([]language.Tag, 0, len(m))
+	for tag := range m {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].String() < tags[j].String()
+	})
+	return tags
+}
@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+type testReporter struct {
+	errs []string
+}
+
+func (r *testReporter) Helper() {}
+
+func (r *testReporter) Errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestCheckCoverage(t *testing.T) {
+	fsys := fstest.MapFS{
+		"active.en.toml": {Data: []byte(`
+ErrNotFound = "{{.Kind}} {{.Name}} not found"
+ErrDuplicate = "duplicate"
+ErrOld = "old"
+
+[ErrUnread]
+one = "{{.PluralCount}} unread message"
+other = "{{if .Name}}{{.Name}}: {{end}}{{.PluralCount}} unread messages{{range .Items}}{{.Title}}{{end}}"
+`)},
+		"active.ru.toml": {Data: []byte(`
+ErrNotFound = "{{.Name}} не найден"
+ErrUnread = "{{.PluralCount}} непрочитанных сообщений"
+`)},
+	}
+
+	bundle := i18n.NewBundle(language.English)
+	_, report, err := LoadMessagesFS(bundle, fsys)
+	require.NoError(t, err)
+
+	used := []*Error{
+		NotFoundErrWith(SetID("ErrNotFound"), AppendContextInfo("Name", "user")),
+		DuplicateErrWith(SetID("ErrDuplicate")),
+		NewWith(SetID("ErrUnread"), SetPluralCount(2)),
+		NewWith(SetID("ErrNew")),
+		New("no id"),
+	}
+
+	c := CheckCoverage(bundle, report.Files, used...)
+	require.False(t, c.OK())
+	require.Equal(t, map[language.Tag][]string{
+		language.English: {"ErrNew"},
+		language.Russian: {"ErrDuplicate", "ErrNew"},
+	}, c.Missing)
+	require.Equal(t, map[language.Tag][]string{
+		language.English: {"ErrOld"},
+	}, c.Unused)
+	require.Equal(t, []PlaceholderMismatch{
+		{Lang: language.English, ID: "ErrNotFound", Missing: []string{"Kind"}},
+		{Lang: language.English, ID: "ErrUnread", Missing: []string{"Items", "Name"}},
+	}, c.Placeholders)
+
+	r := &testReporter{}
+	require.False(t, AssertCoverage(r, bundle, report.Files, used...))
+	require.Len(t, r.errs, 3+1+2)
+	require.Contains(t, r.errs, `translation "ErrDuplicate" is missing for language "ru"`)
+
+	require.True(t, AssertCoverage(t, bundle, nil, used[0]))
+
+	ids := CheckCoverageIDs(bundle, report.Files, []string{"ErrUnread", "ErrNew", "ErrNotFound", "ErrDuplicate", "ErrNew", ""})
+	require.Equal(t, c.Missing, ids.Missing)
+	require.Equal(t, c.Unused, ids.Unused)
+	require.Empty(t, ids.Placeholders)
+}
@@ -3,8 +3,10 @@ package errors
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
 var (
@@ -81,6 +83,14 @@ type Error struct {
 	id, msg, operation string
 	contextInfo        CtxKV
 	errorType          IErrType
+	retryable          retryState
+	retryAfter         time.Duration
+	retryAt            time.Time
+	violations         []FieldViolation
+	localizer          Localizer
+	templateData       map[string]interface{}
+	pluralCount        interface{}
+	defaultMessage     *i18n.Message
 }
 
 // WithOptions производит параметризацию *Error с помощью функции-парметры Options.
@@ -142,6 +152,49 @@ func (e *Error) ContextInfo() CtxKV {
 	return e.contextInfo
 }
 
+// Retryable вернет признак того, что повтор операции может быть успешным.
+// Если признак не задан явно (см. SetRetryable), он будет определен
+// по наличию задержки RetryAfter или по типу ошибки.
+func (e *Error) Retryable() bool {
+	if e == nil {
+		return false
+	}
+
+	switch {
+	case e.retryable != retryDefault:
+		return e.retryable == retryYes
+	case e.retryAfter > 0, !e.retryAt.IsZero():
+		return true
+	}
+
+	et, _ := GetErrType(e)
+	return retryableType(et)
+}
+
+// RetryAfter вернет рекомендуемую задержку перед повтором операции.
+// Если задано время повтора (см. SetRetryAt), задержка отсчитывается от текущего времени.
+func (e *Error) RetryAfter() time.Duration {
+	if e == nil {
+		return 0
+	}
+	if !e.retryAt.IsZero() {
+		if d := time.Until(e.retryAt); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return e.retryAfter
+}
+
+// RetryAt вернет время, не ранее которого следует повторить операцию.
+// Если задана только задержка (см. SetRetryAfter), вернется нулевое время.
+func (e *Error) RetryAt() time.Time {
+	if e == nil {
+		return time.Time{}
+	}
+	return e.retryAt
+}
+
 // методы форматирования
 
 func mustMarshaler(fn ...Marshaller) Marshaller {
@@ -196,7 +249,7 @@ func (e *Error) Format(s fmt.State, verb rune) { //nolint:cyclop
 
 	case 'v':
 		if s.Flag('#') {
-			spew.Fdump(s, e)
+			spew.Fdump(s, e.dumpable())
 			return
 		}
 		_ = mustMarshaler().MarshalTo(e, s)
@@ -232,7 +285,17 @@ func (e *Error) Sdump() string {
 	if e == nil {
 		return ""
 	}
-	return spew.Sdump(e)
+	return spew.Sdump(e.dumpable())
+}
+
+// dumpable вернет копию ошибки для дампа: без локализатора и данных шаблона перевода,
+// которые могут быть большими или содержать персональные данные.
+func (e *Error) dumpable() *Error {
+	d := *e
+	d.localizer = nil
+	d.templateData = nil
+	d.pluralCount = nil
+	return &d
 }
 
 // log
@@ -257,6 +320,9 @@ func (e *Error) Error() string {
 	return b2s(data)
 }
 
+// Is сообщит, является ли target той же *Error (используется errors.Is).
+// Ошибки сравниваются по указателю: копии, полученные с помощью WithOptions,
+// исходной ошибке не соответствуют (для них используйте ContainsByID).
 func (e *Error) Is(target error) bool {
 	if x, ok := target.(*Error); ok { //nolint:errorlint
 		return e == x
@@ -124,6 +124,9 @@ func ParseErrType(s string) IErrType { //nolint:cyclop
 	case "SubscriptionExpired":
 		t = SubscriptionExpired
 
+	case "DownstreamDependencyTimedout":
+		t = DownstreamDependencyTimedout
+
 	case "Unavailable":
 		t = Unavailable
 	}
@@ -207,14 +210,33 @@ func (et errType) GRPCStatusCode() codes.Code { //nolint:cyclop
 // * in: error
 // * out: t errType, ok bool
 // Если error кастится на (*Error), то ok == true, и возвращается значение errType.
+// Для Multierror тип определяется ошибкой, выбранной DefaultAggregatePolicy.
+// Прочие ошибки классифицируются с помощью DefaultClassifier (см. Classify),
+// ok == true, если удалось определить тип.
 // В противном случае возвращается defaultErrType и false.
 func GetErrType(err error) (IErrType, bool) {
+	if errType, ok := errTypeOf(err); ok || err == nil || DefaultClassifier == nil {
+		return errType, ok
+	}
+	return DefaultClassifier.Classify(err)
+}
+
+// errTypeOf вернет тип *Error или Multierror.
+func errTypeOf(err error) (IErrType, bool) {
 	var errType IErrType
 	errType = defaultErrType
 	ok := false
 
-	if e, eok := err.(*Error); eok { //nolint:errorlint
-		errType = e.ErrorType()
+	e, eok := err.(*Error)               //nolint:errorlint
+	if _, mok := err.(Multierror); mok { //nolint:errorlint
+		e = aggregate(err)
+		eok = e != nil
+	}
+
+	if eok {
+		if et := e.ErrorType(); et != nil {
+			errType = et
+		}
 		ok = true
 	}
 
@@ -20,11 +20,12 @@ func _() {
 	_ = x[MaximumAttempts-10]
 	_ = x[SubscriptionExpired-11]
 	_ = x[DownstreamDependencyTimedout-12]
+	_ = x[Unavailable-13]
 }
 
-const _errType_name = "UnknownInternalValidationInputBodyDuplicateUnauthenticatedUnauthorizedEmptyNotFoundMaximumAttemptsSubscriptionExpiredDownstreamDependencyTimedout"
+const _errType_name = "UnknownInternalValidationInputBodyDuplicateUnauthenticatedUnauthorizedEmptyNotFoundMaximumAttemptsSubscriptionExpiredDownstreamDependencyTimedoutUnavailable"
 
-var _errType_index = [...]uint8{0, 7, 15, 25, 34, 43, 58, 70, 75, 83, 98, 117, 145}
+var _errType_index = [...]uint8{0, 7, 15, 25, 34, 43, 58, 70, 75, 83, 98, 117, 145, 156}
 
 func (i errType) String() string {
 	i -= 1
@@ -0,0 +1,425 @@
+// Package extract поиск сообщений ошибок в исходных кодах Go-модуля
+// и обновление файлов переводов active.<язык>.toml.
+//
+// Сообщения ищутся в синтаксическом дереве (go/ast):
+// * пары SetID/SetMsg в аргументах вызовов (NewWith, XxxErrWith, WithOptions и т.п.)
+// и в литералах срезов []errors.Options;
+// * литералы i18n.Message.
+//
+// ID и тексты должны быть строковыми константами: литералами
+// или константами пакетов модуля (в т.ч. из других пакетов модуля).
+package extract
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/ovsinc/errors"
+)
+
+const (
+	// ErrorsImportPath путь импорта пакета ошибок.
+	ErrorsImportPath = "github.com/ovsinc/errors"
+	// I18nImportPath путь импорта пакета go-i18n.
+	I18nImportPath = "github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// ErrMessageConflict один ID сообщения используется в исходных кодах с разными текстами (см. Scan).
+var ErrMessageConflict = errors.NewWith(
+	errors.SetID("ErrExtractMessageConflict"),
+	errors.SetMsg("message ID is used with different texts"),
+	errors.SetErrorType(errors.Duplicate),
+)
+
+// Message сообщение, найденное в исходных кодах.
+type Message struct {
+	i18n.Message
+	// Pos позиция первого вхождения сообщения.
+	Pos token.Position
+}
+
+// Scan найдет сообщения в Go-файлах модуля в каталоге root.
+// Каталоги vendor, testdata, скрытые (".", "_") и вложенные модули пропускаются.
+// Если tests == false, файлы *_test.go пропускаются.
+//
+// Сообщения с одинаковым ID объединяются. Если тексты сообщений с одним ID различаются,
+// используется первое, а в ошибке (Multierror) будут все конфликты (ErrMessageConflict).
+// Сообщения упорядочены по ID.
+func Scan(root string, tests bool) ([]*Message, error) {
+	s := &scanner{
+		fset:   token.NewFileSet(),
+		pkgs:   make(map[string]*pkg),
+		module: modulePath(root),
+	}
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && skipDir(p, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(p, ".go") || (!tests && strings.HasSuffix(p, "_test.go")) {
+			return nil
+		}
+		return s.parseFile(root, p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.messages()
+}
+
+func skipDir(p, name string) bool {
+	if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(p, "go.mod"))
+	return err == nil
+}
+
+// modulePath вернет путь модуля из root/go.mod или "", если файла нет.
+func modulePath(root string) string {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && fields[0] == "module" {
+			if p, err := strconv.Unquote(fields[1]); err == nil {
+				return p
+			}
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// pkg пакет модуля.
+type pkg struct {
+	path  string
+	files []*ast.File
+	// consts выражения констант пакета
+	consts map[string]ast.Expr
+}
+
+type scanner struct {
+	fset   *token.FileSet
+	module string
+	pkgs   map[string]*pkg
+	// order пути пакетов в порядке обхода
+	order []string
+}
+
+func (s *scanner) parseFile(root, p string) error {
+	f, err := parser.ParseFile(s.fset, p, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(root, filepath.Dir(p))
+	if err != nil {
+		return err
+	}
+	importPath := path.Join(s.module, filepath.ToSlash(rel))
+	// внешние тестовые пакеты (xxx_test) не видят констант основного пакета
+	if strings.HasSuffix(f.Name.Name, "_test") {
+		importPath += "_test"
+	}
+
+	pk, ok := s.pkgs[importPath]
+	if !ok {
+		pk = &pkg{path: importPath, consts: make(map[string]ast.Expr)}
+		s.pkgs[importPath] = pk
+		s.order = append(s.order, importPath)
+	}
+	pk.files = append(pk.files, f)
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range vs.Names {
+				if i < len(vs.Values) {
+					pk.consts[name.Name] = vs.Values[i]
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *scanner) messages() ([]*Message, error) {
+	var (
+		byID = make(map[string]*Message)
+		errs []error
+	)
+
+	add := func(m *Message) {
+		prev, ok := byID[m.ID]
+		if !ok {
+			byID[m.ID] = m
+			return
+		}
+		if m.Other != "" && prev.Other != "" && m.Other != prev.Other {
+			errs = append(errs, ErrMessageConflict.WithOptions(
+				errors.AppendContextInfo("id", m.ID),
+				errors.AppendContextInfo("first", prev.Pos.String()),
+				errors.AppendContextInfo("second", m.Pos.String()),
+			))
+			return
+		}
+		mergeMessage(&prev.Message, &m.Message)
+	}
+
+	for _, p := range s.order {
+		pk := s.pkgs[p]
+		for _, f := range pk.files {
+			v := &fileScanner{scanner: s, pkg: pk, imports: fileImports(f)}
+			ast.Inspect(f, func(n ast.Node) bool {
+				if m := v.node(n); m != nil {
+					add(m)
+				}
+				return true
+			})
+		}
+	}
+
+	msgs := make([]*Message, 0, len(byID))
+	for _, m := range byID {
+		msgs = append(msgs, m)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].ID < msgs[j].ID })
+
+	if len(errs) > 0 {
+		return msgs, errors.Combine(errs...)
+	}
+	return msgs, nil
+}
+
+// mergeMessage дополнит dst незаданными полями src.
+func mergeMessage(dst, src *i18n.Message) {
+	for _, f := range []struct{ d, s *string }{
+		{&dst.Description, &src.Description},
+		{&dst.Zero, &src.Zero},
+		{&dst.One, &src.One},
+		{&dst.Two, &src.Two},
+		{&dst.Few, &src.Few},
+		{&dst.Many, &src.Many},
+		{&dst.Other, &src.Other},
+	} {
+		if *f.d == "" {
+			*f.d = *f.s
+		}
+	}
+}
+
+// fileImports вернет пути импорта файла по локальным именам ("." для импорта через точку).
+func fileImports(f *ast.File) map[string]string {
+	imports := make(map[string]string, len(f.Imports))
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(p)
+		switch {
+		case spec.Name != nil:
+			name = spec.Name.Name
+		case p == I18nImportPath:
+			name = "i18n"
+		case strings.HasPrefix(name, "v") && strings.Count(p, "/") > 0:
+			if _, err := strconv.Atoi(name[1:]); err == nil {
+				name = path.Base(path.Dir(p))
+			}
+		}
+		imports[name] = p
+	}
+	return imports
+}
+
+type fileScanner struct {
+	*scanner
+	pkg     *pkg
+	imports map[string]string
+}
+
+// node вернет сообщение, найденное в узле n, или nil.
+func (v *fileScanner) node(n ast.Node) *Message {
+	switch x := n.(type) {
+	case *ast.CallExpr:
+		return v.options(x.Args)
+	case *ast.CompositeLit:
+		if v.isName(x.Type, I18nImportPath, "Message") {
+			return v.i18nMessage(x)
+		}
+		return v.options(x.Elts)
+	}
+	return nil
+}
+
+// options вернет сообщение из пары SetID/SetMsg списка опций args.
+func (v *fileScanner) options(args []ast.Expr) *Message {
+	var (
+		m     *Message
+		other string
+	)
+	for _, arg := range args {
+		call, ok := arg.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			continue
+		}
+		switch {
+		case v.isName(call.Fun, ErrorsImportPath, "SetID"):
+			id, ok := v.eval(v.pkg, v.imports, call.Args[0], 0)
+			if !ok || id == "" {
+				return nil
+			}
+			m = &Message{Pos: v.fset.Position(call.Pos())}
+			m.ID = id
+		case v.isName(call.Fun, ErrorsImportPath, "SetMsg"):
+			if s, ok := v.eval(v.pkg, v.imports, call.Args[0], 0); ok {
+				other = s
+			}
+		}
+	}
+	if m != nil {
+		m.Other = other
+	}
+	return m
+}
+
+// i18nMessage вернет сообщение из литерала i18n.Message.
+func (v *fileScanner) i18nMessage(lit *ast.CompositeLit) *Message {
+	m := &Message{Pos: v.fset.Position(lit.Pos())}
+	fields := map[string]*string{
+		"ID":          &m.ID,
+		"Description": &m.Description,
+		"Zero":        &m.Zero,
+		"One":         &m.One,
+		"Two":         &m.Two,
+		"Few":         &m.Few,
+		"Many":        &m.Many,
+		"Other":       &m.Other,
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if f, ok := fields[key.Name]; ok {
+			*f, _ = v.eval(v.pkg, v.imports, kv.Value, 0)
+		}
+	}
+
+	if m.ID == "" {
+		return nil
+	}
+	return m
+}
+
+// isName проверит, что expr -- идентификатор name пакета importPath.
+func (v *fileScanner) isName(expr ast.Expr, importPath, name string) bool {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		if x.Name != name {
+			return false
+		}
+		if v.pkg.path == importPath || strings.TrimSuffix(v.pkg.path, "_test") == importPath {
+			return true
+		}
+		return v.imports["."] == importPath
+	case *ast.SelectorExpr:
+		id, ok := x.X.(*ast.Ident)
+		return ok && x.Sel.Name == name && v.imports[id.Name] == importPath
+	}
+	return false
+}
+
+// maxConstDepth ограничение глубины вычисления констант.
+const maxConstDepth = 32
+
+// eval вычислит строковое константное выражение expr пакета pk.
+func (s *scanner) eval(pk *pkg, imports map[string]string, expr ast.Expr, depth int) (string, bool) {
+	if depth > maxConstDepth {
+		return "", false
+	}
+
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		if x.Kind != token.STRING {
+			return "", false
+		}
+		v, err := strconv.Unquote(x.Value)
+		return v, err == nil
+	case *ast.ParenExpr:
+		return s.eval(pk, imports, x.X, depth+1)
+	case *ast.BinaryExpr:
+		if x.Op != token.ADD {
+			return "", false
+		}
+		a, ok := s.eval(pk, imports, x.X, depth+1)
+		if !ok {
+			return "", false
+		}
+		b, ok := s.eval(pk, imports, x.Y, depth+1)
+		return a + b, ok
+	case *ast.Ident:
+		if v, ok := pk.consts[x.Name]; ok {
+			return s.evalConst(pk, v, depth)
+		}
+	case *ast.SelectorExpr:
+		id, ok := x.X.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		if other, ok := s.pkgs[imports[id.Name]]; ok {
+			if v, ok := other.consts[x.Sel.Name]; ok {
+				return s.evalConst(other, v, depth)
+			}
+		}
+	}
+	return "", false
+}
+
+// evalConst вычислит константу пакета pk в контексте файла, в котором она объявлена.
+func (s *scanner) evalConst(pk *pkg, expr ast.Expr, depth int) (string, bool) {
+	imports := map[string]string{}
+	for _, f := range pk.files {
+		if f.Pos() <= expr.Pos() && expr.End() <= f.End() {
+			imports = fileImports(f)
+			break
+		}
+	}
+	return s.eval(pk, imports, expr, depth+1)
+}
@@ -0,0 +1,128 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ovsinc/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for name, data := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(data), 0o600))
+	}
+	return root
+}
+
+func TestScan(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"go.mod": "module example.com/app\n",
+		"ids/ids.go": `package ids
+
+const (
+	Prefix = "ErrUser"
+	NotFoundID = Prefix + "NotFound"
+)
+`,
+		"app.go": `package app
+
+import (
+	errs "github.com/ovsinc/errors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"example.com/app/ids"
+)
+
+const dupMsg = "duplicate " + "user"
+
+var (
+	ErrNotFound = errs.NotFoundErrWith(errs.SetID(ids.NotFoundID), errs.SetMsg("user not found"))
+	ErrDup      = errs.NewWith(errs.SetErrorType(errs.Duplicate), errs.SetMsg(dupMsg), errs.SetID("ErrUserDup"))
+	ErrNoMsg    = errs.NewWith(errs.SetID("ErrNoMsg"))
+	ErrDynamic  = errs.NewWith(errs.SetID(dynamicID()), errs.SetMsg("dynamic"))
+
+	dupOps = []errs.Options{errs.SetID("ErrUserDup"), errs.SetMsg(dupMsg)}
+
+	FilesMsg = &i18n.Message{
+		ID:          "ErrFiles",
+		Description: "files count",
+		One:         "{{.PluralCount}} file",
+		Other:       "{{.PluralCount}} files",
+	}
+)
+
+func dynamicID() string { return "x" }
+
+func SetID(string) {}
+
+func local() { SetID("ErrNotErrorsPkg") }
+`,
+		"app_test.go": `package app
+
+import "github.com/ovsinc/errors"
+
+var errTest = errors.NewWith(errors.SetID("ErrTest"))
+`,
+		"vendor/x/x.go": `package x
+
+import "github.com/ovsinc/errors"
+
+var errVendor = errors.NewWith(errors.SetID("ErrVendor"))
+`,
+		"nested/go.mod": "module example.com/nested\n",
+		"nested/x.go": `package nested
+
+import . "github.com/ovsinc/errors"
+
+var errNested = NewWith(SetID("ErrNested"))
+`,
+	})
+
+	msgs, err := Scan(root, false)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+	require.Equal(t, []string{"ErrFiles", "ErrNoMsg", "ErrUserDup", "ErrUserNotFound"}, ids)
+
+	require.Equal(t, "files count", msgs[0].Description)
+	require.Equal(t, "{{.PluralCount}} file", msgs[0].One)
+	require.Equal(t, "{{.PluralCount}} files", msgs[0].Other)
+	require.Empty(t, msgs[1].Other)
+	require.Equal(t, "duplicate user", msgs[2].Other)
+	require.Equal(t, "user not found", msgs[3].Other)
+	require.Equal(t, filepath.Join(root, "app.go"), msgs[3].Pos.Filename)
+
+	msgs, err = Scan(root, true)
+	require.NoError(t, err)
+	require.Len(t, msgs, 5)
+	require.Equal(t, "ErrTest", msgs[2].ID)
+}
+
+func TestScanConflict(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"a.go": `package a
+
+import . "github.com/ovsinc/errors"
+
+var (
+	errA = NewWith(SetID("ErrA"), SetMsg("first"))
+	errB = IternalErrWith(SetID("ErrA"), SetMsg("second"))
+)
+`,
+	})
+
+	msgs, err := Scan(root, false)
+	require.True(t, errors.ContainsByID(err, ErrMessageConflict.ID()))
+	require.Len(t, msgs, 1)
+	require.Equal(t, "first", msgs[0].Other)
+}
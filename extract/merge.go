@@ -0,0 +1,250 @@
+package extract
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// Пометки сообщений в файле переводов (комментарий перед сообщением).
+const (
+	// MarkNew сообщение без перевода.
+	MarkNew = "new"
+	// MarkChanged исходное сообщение изменилось после перевода.
+	// Чтобы подтвердить перевод, нужно удалить строку hash сообщения.
+	MarkChanged = "changed"
+	// MarkObsolete сообщение не используется в исходных кодах.
+	MarkObsolete = "obsolete"
+)
+
+// MergeReport отчет об объединении файла переводов (см. Merge).
+type MergeReport struct {
+	// New ID сообщений без перевода (для исходного языка -- добавленные в файл).
+	New []string
+	// Changed ID сообщений, исходный текст которых изменился.
+	Changed []string
+	// Obsolete ID сообщений, не найденных в исходных кодах.
+	Obsolete []string
+	// Removed ID удаленных устаревших сообщений (см. Merge prune).
+	Removed []string
+}
+
+// Merge объединит сообщения msgs с содержимым файла переводов active.<lang>.toml old
+// (nil, если файла нет) и вернет новое содержимое файла в формате goi18n.
+//
+// Для исходного языка source тексты сообщений берутся из msgs,
+// а если в msgs у сообщения нет текста -- сохраняются из old.
+// Для остальных языков существующие переводы сохраняются, у каждого сообщения сохраняется
+// hash исходного текста (как в goi18n), новые сообщения добавляются с пустым переводом
+// (go-i18n считает их непереведенными).
+// Новые, измененные (в msgs есть текст, а hash не совпадает) и устаревшие сообщения
+// помечаются комментарием (см. MarkNew, MarkChanged, MarkObsolete);
+// в файле исходного языка новые сообщения не помечаются.
+// Устаревшие сообщения сохраняются, если prune == false.
+func Merge(
+	old []byte, lang, source language.Tag, msgs []*Message, prune bool,
+) ([]byte, *MergeReport, error) {
+	existing := make(map[string]*i18n.Message)
+	if len(old) > 0 {
+		mf, err := i18n.ParseMessageFileBytes(
+			old, "active."+lang.String()+".toml",
+			map[string]i18n.UnmarshalFunc{"toml": toml.Unmarshal},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, m := range mf.Messages {
+			existing[m.ID] = m
+		}
+	}
+
+	isSource := lang == source
+	report := &MergeReport{}
+	entries := make([]*entry, 0, len(msgs)+len(existing))
+	used := make(map[string]struct{}, len(msgs))
+
+	for _, m := range msgs {
+		used[m.ID] = struct{}{}
+		prev := existing[m.ID]
+
+		if isSource {
+			e := &entry{msg: m.Message}
+			switch {
+			case prev == nil:
+				// без пометки: иначе повторный запуск изменит файл (см. cmd/errors-extract -check)
+				report.New = append(report.New, m.ID)
+			case !translated(&m.Message):
+				// текст сообщения в коде не найден: сохраняется текст из файла
+				e.msg = *prev
+				if m.Description != "" {
+					e.msg.Description = m.Description
+				}
+			case forms(prev) != forms(&m.Message):
+				report.Changed = append(report.Changed, m.ID)
+			}
+			if e.msg.Description == "" && prev != nil {
+				e.msg.Description = prev.Description
+			}
+			entries = append(entries, e)
+			continue
+		}
+
+		h := hash(&m.Message)
+		e := &entry{source: m.Other}
+		switch {
+		case prev == nil || !translated(prev):
+			e.msg = i18n.Message{ID: m.ID, Description: m.Description, Hash: h}
+			e.mark = MarkNew
+			report.New = append(report.New, m.ID)
+		case prev.Hash != "" && prev.Hash != h && translated(&m.Message):
+			e.msg = *prev
+			e.mark = MarkChanged
+			report.Changed = append(report.Changed, m.ID)
+		default:
+			e.msg = *prev
+			if translated(&m.Message) {
+				e.msg.Hash = h
+			}
+			e.source = ""
+		}
+		if m.Description != "" {
+			e.msg.Description = m.Description
+		}
+		entries = append(entries, e)
+	}
+
+	for id, prev := range existing {
+		if _, ok := used[id]; ok {
+			continue
+		}
+		if prune {
+			report.Removed = append(report.Removed, id)
+			continue
+		}
+		report.Obsolete = append(report.Obsolete, id)
+		entries = append(entries, &entry{msg: *prev, mark: MarkObsolete})
+	}
+	sort.Strings(report.New)
+	sort.Strings(report.Changed)
+	sort.Strings(report.Obsolete)
+	sort.Strings(report.Removed)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].msg.ID < entries[j].msg.ID })
+
+	var buf bytes.Buffer
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		e.write(&buf, isSource)
+	}
+
+	return buf.Bytes(), report, nil
+}
+
+// hash хеш исходного сообщения, как в goi18n.
+func hash(m *i18n.Message) string {
+	h := sha1.New() //nolint:gosec
+	h.Write([]byte(m.Description))
+	h.Write([]byte(m.Other))
+	return fmt.Sprintf("sha1-%x", h.Sum(nil))
+}
+
+func translated(m *i18n.Message) bool {
+	return m.Zero != "" || m.One != "" || m.Two != "" || m.Few != "" || m.Many != "" || m.Other != ""
+}
+
+func forms(m *i18n.Message) [6]string {
+	return [6]string{m.Zero, m.One, m.Two, m.Few, m.Many, m.Other}
+}
+
+// entry сообщение файла переводов.
+type entry struct {
+	msg  i18n.Message
+	mark string
+	// source исходный текст для переводчика
+	source string
+}
+
+func (e *entry) write(buf *bytes.Buffer, isSource bool) {
+	if e.mark != "" {
+		buf.WriteString("# " + e.mark + "\n")
+	}
+	if e.source != "" {
+		buf.WriteString("# source: " + tomlString(e.source) + "\n")
+	}
+
+	buf.WriteString("[" + tomlKey(e.msg.ID) + "]\n")
+
+	// ключи в алфавитном порядке, как в goi18n
+	kv := func(k, v string) {
+		if v != "" {
+			buf.WriteString(k + " = " + tomlString(v) + "\n")
+		}
+	}
+	kv("description", e.msg.Description)
+	kv("few", e.msg.Few)
+	if !isSource {
+		kv("hash", e.msg.Hash)
+	}
+	kv("many", e.msg.Many)
+	kv("one", e.msg.One)
+	if translated(&e.msg) {
+		kv("other", e.msg.Other)
+	} else {
+		// сообщение без перевода
+		buf.WriteString("other = \"\"\n")
+	}
+	kv("two", e.msg.Two)
+	kv("zero", e.msg.Zero)
+}
+
+// tomlKey вернет ключ TOML: без кавычек, если это допустимо.
+func tomlKey(k string) string {
+	if k == "" {
+		return `""`
+	}
+	for _, r := range k {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return tomlString(k)
+		}
+	}
+	return k
+}
+
+// tomlString вернет базовую строку TOML в кавычках.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
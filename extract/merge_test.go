@@ -0,0 +1,170 @@
+package extract
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func testMessages() []*Message {
+	return []*Message{
+		{Message: i18n.Message{ID: "ErrChanged", Other: "new text"}},
+		{Message: i18n.Message{ID: "ErrKept", Description: "kept", Other: "kept \"text\"\n"}},
+		{Message: i18n.Message{ID: "ErrNew", One: "{{.Count}} item", Other: "{{.Count}} items"}},
+		{Message: i18n.Message{ID: "Err.Dotted", Other: "dotted"}},
+	}
+}
+
+func TestMergeSource(t *testing.T) {
+	old := []byte(`
+[ErrChanged]
+other = "old text"
+
+[ErrKept]
+other = "kept \"text\"\n"
+
+[ErrObsolete]
+other = "obsolete"
+`)
+
+	data, report, err := Merge(old, language.English, language.English, testMessages(), false)
+	require.NoError(t, err)
+	require.Equal(t, &MergeReport{
+		New:      []string{"Err.Dotted", "ErrNew"},
+		Changed:  []string{"ErrChanged"},
+		Obsolete: []string{"ErrObsolete"},
+	}, report)
+	require.Equal(t, `["Err.Dotted"]
+other = "dotted"
+
+[ErrChanged]
+other = "new text"
+
+[ErrKept]
+description = "kept"
+other = "kept \"text\"\n"
+
+[ErrNew]
+one = "{{.Count}} item"
+other = "{{.Count}} items"
+
+# obsolete
+[ErrObsolete]
+other = "obsolete"
+`, string(data))
+
+	data, report, err = Merge(data, language.English, language.English, testMessages(), true)
+	require.NoError(t, err)
+	require.Equal(t, &MergeReport{Removed: []string{"ErrObsolete"}}, report)
+	require.NotContains(t, string(data), "# ")
+
+	// повторное объединение не изменяет файл
+	again, report, err := Merge(data, language.English, language.English, testMessages(), true)
+	require.NoError(t, err)
+	require.Equal(t, &MergeReport{}, report)
+	require.Equal(t, string(data), string(again))
+}
+
+func TestMergeTranslation(t *testing.T) {
+	msgs := testMessages()
+	oldHash := hash(&i18n.Message{Other: "old text"})
+
+	old := []byte(`
+[ErrChanged]
+hash = "` + oldHash + `"
+other = "старый текст"
+
+[ErrKept]
+other = "сохранено"
+
+[ErrNew]
+other = ""
+
+[ErrObsolete]
+other = "устарело"
+`)
+
+	data, report, err := Merge(old, language.Russian, language.English, msgs, false)
+	require.NoError(t, err)
+	require.Equal(t, &MergeReport{
+		New:      []string{"Err.Dotted", "ErrNew"},
+		Changed:  []string{"ErrChanged"},
+		Obsolete: []string{"ErrObsolete"},
+	}, report)
+	require.Equal(t, `# new
+# source: "dotted"
+["Err.Dotted"]
+hash = "`+hash(&msgs[3].Message)+`"
+other = ""
+
+# changed
+# source: "new text"
+[ErrChanged]
+hash = "`+oldHash+`"
+other = "старый текст"
+
+[ErrKept]
+description = "kept"
+hash = "`+hash(&msgs[1].Message)+`"
+other = "сохранено"
+
+# new
+# source: "{{.Count}} items"
+[ErrNew]
+hash = "`+hash(&msgs[2].Message)+`"
+other = ""
+
+# obsolete
+[ErrObsolete]
+other = "устарело"
+`, string(data))
+
+	// результат загружается в go-i18n, сообщения без перевода считаются отсутствующими
+	bundle := i18n.NewBundle(language.English)
+	bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
+	_, err = bundle.ParseMessageFileBytes(data, "active.ru.toml")
+	require.NoError(t, err)
+
+	l := i18n.NewLocalizer(bundle, "ru")
+	msg, err := l.Localize(&i18n.LocalizeConfig{MessageID: "ErrKept"})
+	require.NoError(t, err)
+	require.Equal(t, "сохранено", msg)
+	_, err = l.Localize(&i18n.LocalizeConfig{MessageID: "ErrNew"})
+	require.Error(t, err)
+
+	// повторное объединение не меняет файл
+	again, _, err := Merge(data, language.Russian, language.English, msgs, false)
+	require.NoError(t, err)
+	require.Equal(t, string(data), string(again))
+}
+
+func TestMergeNoText(t *testing.T) {
+	h := hash(&i18n.Message{Other: "text"})
+	msgs := []*Message{{Message: i18n.Message{ID: "ErrNoText"}}}
+
+	data, report, err := Merge([]byte(`
+[ErrNoText]
+other = "text"
+`), language.English, language.English, msgs, false)
+	require.NoError(t, err)
+	require.Equal(t, &MergeReport{}, report)
+	require.Equal(t, "[ErrNoText]\nother = \"text\"\n", string(data))
+
+	data, report, err = Merge([]byte(`
+[ErrNoText]
+hash = "`+h+`"
+other = "текст"
+`), language.Russian, language.English, msgs, false)
+	require.NoError(t, err)
+	require.Equal(t, &MergeReport{}, report)
+	require.Equal(t, "[ErrNoText]\nhash = \""+h+"\"\nother = \"текст\"\n", string(data))
+}
+
+func TestTOMLString(t *testing.T) {
+	require.Equal(t, `"a\"b\\c\t\n\u0001é"`, tomlString("a\"b\\c\t\n\x01é"))
+	require.Equal(t, "Err_1-a", tomlKey("Err_1-a"))
+	require.Equal(t, `"a b"`, tomlKey("a b"))
+}
@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// GroupOption опции-параметры Group.
+type GroupOption func(g *Group)
+
+// GroupLimit, целое. Ограничит число одновременно выполняемых функций.
+// При n <= 0 ограничения нет.
+func GroupLimit(n int) GroupOption {
+	return func(g *Group) {
+		if n <= 0 {
+			g.sem = nil
+			return
+		}
+		g.sem = make(chan struct{}, n)
+	}
+}
+
+// GroupCancelOnError отменит контекст группы при первой ошибке.
+func GroupCancelOnError() GroupOption {
+	return GroupCancelThreshold(1)
+}
+
+// GroupCancelThreshold, целое. Отменит контекст группы, когда число ошибок достигнет n.
+// При n <= 0 контекст не отменяется до завершения Wait.
+func GroupCancelThreshold(n int) GroupOption {
+	return func(g *Group) {
+		g.threshold = n
+	}
+}
+
+// GroupMaxErrors, целое и TruncateMode. Ограничит число сохраняемых ошибок группы.
+// Ошибки сверх лимита учитываются в Multierror.Truncated(). См. CombineBounded.
+func GroupMaxErrors(n int, mode TruncateMode) GroupOption {
+	return func(g *Group) {
+		g.errs.limit = n
+		g.errs.mode = mode
+	}
+}
+
+// Group группа горутин, ошибки которых собираются в Multierror.
+// Паника в горутине перехватывается и превращается в *Error с типом Internal и стеком вызовов.
+// Нулевое значение Group готово к использованию: без ограничений и без контекста.
+type Group struct {
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	cancel    context.CancelFunc
+	threshold int
+
+	mu     sync.Mutex
+	errs   bounded
+	failed int
+}
+
+// NewGroup конструктор *Group.
+// Вернет группу и производный от ctx контекст, который будет отменен
+// по достижении порога ошибок (см. GroupCancelThreshold) или при завершении Wait.
+// * ops ...GroupOption -- параметризация через функции-парметры.
+func NewGroup(ctx context.Context, ops ...GroupOption) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group{cancel: cancel}
+	for _, op := range ops {
+		op(g)
+	}
+	return g, ctx
+}
+
+// Go выполнит fn в новой горутине.
+// Если задан GroupLimit, вызов будет заблокирован до освобождения места в группе.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		defer func() {
+			if r := recover(); r != nil {
+				g.add(panicErr(r))
+			}
+		}()
+
+		if err := fn(); err != nil {
+			g.add(err)
+		}
+	}()
+}
+
+// Wait дождется завершения всех функций группы и вернет их ошибки.
+// Если ошибок не было, вернется nil.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.errs.err()
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+func (g *Group) add(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.errs.add(err)
+	g.failed++
+	if g.cancel != nil && g.threshold > 0 && g.failed >= g.threshold {
+		g.cancel()
+	}
+}
+
+func panicErr(r interface{}) *Error {
+	return IternalErrWith(
+		SetMsg(fmt.Sprintf("panic: %v", r)),
+		AppendContextInfo("stack", string(debug.Stack())),
+	)
+}
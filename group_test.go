@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupNoErrors(t *testing.T) {
+	var g Group
+	for i := 0; i < 10; i++ {
+		g.Go(func() error { return nil })
+	}
+	require.NoError(t, g.Wait())
+}
+
+func TestGroupCollect(t *testing.T) {
+	g, _ := NewGroup(context.Background())
+
+	e1 := New("one")
+	e2 := New("two")
+
+	g.Go(func() error { return e1 })
+	g.Go(func() error { return nil })
+	g.Go(func() error { return e2 })
+
+	err := g.Wait()
+	merr, ok := err.(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.ElementsMatch(t, []error{e1, e2}, merr.Errors())
+}
+
+func TestGroupPanic(t *testing.T) {
+	var g Group
+	g.Go(func() error { panic("boom") })
+
+	err := g.Wait()
+	e, ok := err.(*Error) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, Internal, e.ErrorType())
+	require.Equal(t, "panic: boom", e.Msg())
+	require.Len(t, e.ContextInfo(), 1)
+	require.Equal(t, "stack", e.ContextInfo()[0].Key)
+	require.True(t, strings.Contains(e.ContextInfo()[0].Value.(string), "TestGroupPanic")) //nolint:forcetypeassert
+}
+
+func TestGroupCancelOnError(t *testing.T) {
+	g, ctx := NewGroup(context.Background(), GroupCancelOnError())
+
+	g.Go(func() error { return New("fail") })
+	g.Go(func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+
+	err := g.Wait()
+	require.True(t, ContainsByErr(err, context.Canceled))
+}
+
+func TestGroupLimit(t *testing.T) {
+	g, _ := NewGroup(context.Background(), GroupLimit(2))
+
+	var running, peak int32
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+
+	require.NoError(t, g.Wait())
+	require.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func TestGroupMaxErrors(t *testing.T) {
+	g, _ := NewGroup(context.Background(), GroupMaxErrors(2, TruncateKeepFirst), GroupLimit(1))
+
+	for i := 0; i < 5; i++ {
+		g.Go(func() error { return New("fail") })
+	}
+
+	merr, ok := g.Wait().(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, 2, merr.Len())
+	require.Equal(t, 3, merr.Truncated())
+}
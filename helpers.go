@@ -2,6 +2,7 @@ package errors
 
 import (
 	origerrors "errors"
+	"reflect"
 )
 
 // GetID возвращает ID ошибки. Для НЕ *Error всегда будет "".
@@ -12,84 +13,93 @@ func GetID(err error) string {
 	return ""
 }
 
-// GetOperation возвращает операцию ошибки. Для НЕ *Error всегда будет "".
+// GetOperation возвращает операцию ошибки.
+// Для Multierror вернется имя операции узла дерева ошибок (см. CombineTree).
+// Для прочих ошибок всегда будет "".
 func GetOperation(err error) string {
-	if e, ok := err.(*Error); ok { //nolint:errorlint
-		return e.Operation()
+	switch t := err.(type) { //nolint:errorlint
+	case *Error:
+		return t.Operation()
+	case Multierror:
+		return t.Operation()
 	}
 	return ""
 }
 
+// Find вернет первую ошибку дерева err, для которой fn вернет true.
+// Дерево обходится в глубину (см. Walk), списки ошибок (Multierror и т.п.)
+// передаются в fn после своих элементов.
+// Если ошибка не найдена, вернется nil.
 func Find(err error, fn func(error) bool) error {
-	switch t := err.(type) { //nolint:errorlint
-	case *multiError:
-		for _, e := range t.errors {
-			if fn(e) {
-				return e
-			}
-		}
-	case *Error:
-		if fn(t) {
-			return t
-		}
-	case error:
-		if fn(t) {
-			return t
+	if err == nil {
+		return nil
+	}
+
+	var found error
+	match := func(e error, _ int) bool {
+		if fn(e) {
+			found = e
+			return true
 		}
+		return false
+	}
+
+	w := walker{
+		fn: func(e error, depth int) bool {
+			if _, ok := listErrors(e); ok {
+				return false
+			}
+			return match(e, depth)
+		},
+		post: match,
 	}
-	return nil
+	w.walk(err, 0)
+	return found
 }
 
 // FindByID вернет ошибку (*Error) с указанным ID.
 // Если ошибка с указанным ID не найдена, вернется nil.
 func FindByID(err error, id string) error {
-	return Find(err, func(e error) bool {
-		ee, ok := e.(*Error) //nolint:errorlint
-		return ok && ee.ID() == id
-	})
+	return Find(err, MatchID(id))
 }
 
 // FindByErr вернет ошибку (*Error) соответсвующую target или nil.
 // Если ошибка не найдена, вернется nil.
 func FindByErr(err error, target error) error {
-	return Find(err, func(e error) bool {
-		return origerrors.Is(e, target)
-	})
+	return Find(err, MatchErr(target))
 }
 
-// Contains проверит есть ли в цепочке целевая ошибка.
+// Contains проверит есть ли в дереве err ошибка, для которой fn вернет true.
 // Допускается в качестве аргумента err указывать одиночную ошибку.
 func Contains(err error, fn func(error) bool) bool {
-	switch t := err.(type) { //nolint:errorlint
-	case *multiError:
-		for _, e := range t.errors {
-			if fn(e) {
-				return true
-			}
-		}
-	case *Error:
-		return fn(err)
-	case error:
-		return fn(err)
-	}
-	return false
+	return Find(err, fn) != nil
 }
 
 // ContainsByID проверит есть ли в цепочке ошибка с указанным ID.
 // Допускается в качестве аргумента err указывать одиночную ошибку.
 func ContainsByID(err error, id string) bool {
-	return Contains(err, func(e error) bool {
-		ee, ok := e.(*Error) //nolint:errorlint
-		return ok && ee.ID() == id
-	})
+	return Contains(err, MatchID(id))
 }
 
 // ContainsByErr проверит есть ли в цепочке ошибка.
 // Допускается в качестве аргумента err указывать одиночную ошибку.
 func ContainsByErr(err error, target error) bool {
-	return Contains(err, func(e error) bool {
-		return origerrors.Is(err, target)
-	})
+	return Contains(err, MatchErr(target))
+}
+
+// isTarget сообщает, соответствует ли ошибка err target-ошибке.
+// В отличие от Is, обернутые ошибки не проверяются: их обходит Walk.
+func isTarget(err, target error) bool {
+	if target == nil {
+		return false
+	}
+	if reflect.TypeOf(target).Comparable() && err == target { //nolint:errorlint
+		return true
+	}
+	if x, ok := err.(interface{ Is(error) bool }); ok { //nolint:errorlint
+		return x.Is(target)
+	}
+	return false
 }
 
 // Is сообщает, соответствует ли ошибка err target-ошибке.
@@ -98,19 +108,26 @@ func Is(err, target error) bool {
 	if err == nil {
 		return target == nil
 	}
-	return origerrors.Is(err, target)
+	return is(err, target)
 }
 
 // As обнаруживает ошибку err, соответствующую типу target и устанавливает target в найденное значение.
+// Для multierr будет производится поиск в цепочке.
 func As(err error, target interface{}) bool {
-	return origerrors.As(err, target)
+	return as(err, target)
 }
 
+// Unwrap вернет обернутую ошибку.
+// Для Multierror будет возвращена самая новая ошибка в стеке (Last()).
 func Unwrap(err error) error {
+	if merr, ok := err.(Multierror); ok { //nolint:errorlint
+		return merr.Last()
+	}
 	return origerrors.Unwrap(err)
 }
 
 // Cast преобразует тип error в *Error
+// Для Multierror будет возвращена ошибка, выбранная DefaultAggregatePolicy.
 // Если error не соответствует *Error, то будет создан *Error с сообщением err.Error().
 // Для err == nil, вернется nil.
 func Cast(err error) (*Error, bool) {
@@ -121,7 +138,10 @@ func Cast(err error) (*Error, bool) {
 	case *Error:
 		return t, true
 
-	case *multiError:
+	case Multierror:
+		if e := aggregate(t); e != nil {
+			return e, true
+		}
 		return New(t.Last()), true
 
 	default:
@@ -141,6 +161,6 @@ func CastMultierr(err error) (Multierror, bool) {
 		return t, true
 
 	default:
-		return Combine(err).(Multierror), true ////nolint:errorlint
+		return flatten([]error{err}), true
 	}
 }
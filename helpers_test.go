@@ -173,6 +173,10 @@ func TestIs(t *testing.T) {
 
 	erre := errc
 
+	sentinel := NewWith(SetID("ErrSentinel"), SetMsg("sentinel"))
+	copied := sentinel.WithOptions(AppendContextInfo("key", "value"))
+	other := NewWith(SetID("ErrOther"), SetMsg("sentinel"))
+
 	testCases := []struct {
 		err    error
 		target error
@@ -188,6 +192,15 @@ func TestIs(t *testing.T) {
 		{errc, err2, true},
 		{errd, err2, true},
 		{erre, errc, true},
+
+		{copied, sentinel, false},
+		{Combine(err1, copied), sentinel, false},
+		{Combine(sentinel, err1), sentinel, true},
+		{Combine(err1, sentinel), sentinel, true},
+		{Combine(err1, fmt.Errorf("wrap: %w", sentinel)), sentinel, true},
+		{other, sentinel, false},
+		{NewWith(SetID("ErrSentinel"), SetMsg("other")), sentinel, false},
+		{err1, New("1"), false},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -247,6 +260,12 @@ func TestAs(t *testing.T) { //nolint:funlen
 			true,
 			merr2cast,
 		},
+		{
+			Combine(origerrors.New("std"), err1),
+			&errE1,
+			true,
+			err1,
+		},
 	}
 	for i, tc := range testCases {
 		tc := tc
@@ -0,0 +1,282 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderRetryAfter имя HTTP-заголовка с задержкой перед повтором запроса.
+const HeaderRetryAfter = "Retry-After"
+
+// maxHTTPBody максимальный размер тела ответа, читаемого FromHTTPResponse.
+const maxHTTPBody = 64 << 10
+
+// RetryAfterHeader вернет значение заголовка Retry-After для ошибки err.
+// Используется *Error дерева ошибок с наибольшей задержкой: если для нее задано
+// время повтора (см. SetRetryAt), оно выводится в формате HTTP-date, иначе --
+// задержка в секундах.
+// Если задержка не задана, вернется "" и false.
+func RetryAfterHeader(err error) (string, bool) {
+	var found *Error
+	Walk(err, func(e error, _ int) bool {
+		if ee, ok := e.(*Error); ok && ee.RetryAfter() > found.RetryAfter() { //nolint:errorlint
+			found = ee
+		}
+		return false
+	})
+	if found == nil {
+		return "", false
+	}
+
+	if at := found.RetryAt(); !at.IsZero() {
+		return at.UTC().Format(http.TimeFormat), true
+	}
+	return strconv.FormatInt(retryAfterSeconds(found.RetryAfter()), 10), true
+}
+
+// SetHTTPHeaders установит HTTP-заголовки ответа для ошибки err.
+// Сейчас устанавливается только Retry-After (см. RetryAfterHeader).
+func SetHTTPHeaders(h http.Header, err error) {
+	if v, ok := RetryAfterHeader(err); ok {
+		h.Set(HeaderRetryAfter, v)
+	}
+}
+
+// WriteHTTP запишет ошибку err в HTTP-ответ w:
+// заголовки (см. SetHTTPHeaders), статус (см. HTTPStatusCode) и тело в формате JSON.
+// Для err == nil ничего не будет записано.
+// Для перевода сообщений на язык запроса используйте WriteHTTPCtx.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	WriteHTTPCtx(context.Background(), w, err)
+}
+
+// WriteHTTPCtx как и WriteHTTP запишет ошибку err в HTTP-ответ w,
+// но сообщения будут переведены локализатором из ctx (см. LocalizerFrom, LocalizerMiddleware)
+// с учетом псевдолокализации (см. WithPseudoLocalization).
+//
+//	errors.WriteHTTPCtx(r.Context(), w, err)
+func WriteHTTPCtx(ctx context.Context, w http.ResponseWriter, err error) {
+	if err == nil {
+		return
+	}
+
+	SetHTTPHeaders(w.Header(), err)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	status, _ := HTTPStatusCode(err)
+	w.WriteHeader(status)
+
+	_ = (&MarshalJSON{Localizer: localizerCtx(ctx, err)}).MarshalTo(err, w)
+}
+
+// httpErrorBody тело ответа с ошибкой в формате MarshalJSON.
+// У множественной ошибки заполнены Count и Messages.
+type httpErrorBody struct {
+	ID         string   `json:"id"`
+	Operation  string   `json:"operation"`
+	ErrorType  string   `json:"error_type"`
+	Msg        string   `json:"msg"`
+	RetryAfter *float64 `json:"retry_after"`
+
+	Count     *int              `json:"count"`
+	Truncated int               `json:"truncated"`
+	Messages  []json.RawMessage `json:"messages"`
+}
+
+func (b *httpErrorBody) multi() bool {
+	return b.Count != nil || b.Messages != nil
+}
+
+// FromHTTPResponse преобразует HTTP-ответ нижестоящего сервиса с ошибкой в *Error.
+// Тип ошибки определяется по полю error_type тела ответа (формат MarshalJSON),
+// а если его нет -- по статусу (см. ErrTypeFromHTTPStatus).
+// Сообщение берется из поля msg или из тела ответа целиком.
+// Задержка повтора берется из заголовка Retry-After (секунды или HTTP-date)
+// или из поля retry_after.
+// Если тело ответа -- множественная ошибка в формате MarshalJSON, вернется цепочка
+// (или дерево) *Error, к каждой из которых применяются статус, Retry-After и ops.
+// * ops ...Options -- дополнительная параметризация *Error.
+// Тело ответа будет прочитано, но не закрыто.
+// Для успешного ответа (статус < 400) вернется nil.
+func FromHTTPResponse(resp *http.Response, ops ...Options) error {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	head := []Options{SetErrorType(ErrTypeFromHTTPStatus(resp.StatusCode))}
+
+	tail := make([]Options, 0, len(ops)+1)
+	if v := resp.Header.Get(HeaderRetryAfter); v != "" {
+		if sec, err := strconv.ParseInt(v, 10, 64); err == nil && sec >= 0 {
+			tail = append(tail, SetRetryAfter(time.Duration(sec)*time.Second))
+		} else if at, err := http.ParseTime(v); err == nil {
+			tail = append(tail, SetRetryAt(at))
+		}
+	}
+	tail = append(tail, ops...)
+
+	var raw []byte
+	if resp.Body != nil {
+		raw, _ = io.ReadAll(io.LimitReader(resp.Body, maxHTTPBody))
+	}
+
+	var body httpErrorBody
+	if json.Unmarshal(raw, &body) == nil {
+		if err := fromHTTPBody(&body, false, head, tail); err != nil {
+			return err
+		}
+	}
+
+	msg := strings.TrimSpace(string(raw))
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	return NewWith(append(append(head, SetMsg(msg)), tail...)...)
+}
+
+// fromHTTPBody вернет ошибку, описанную телом ответа body.
+// head -- параметры по-умолчанию, tail -- параметры, применяемые последними.
+// Если body не описывает ошибку, вернется nil.
+func fromHTTPBody(body *httpErrorBody, nested bool, head, tail []Options) error {
+	if body.multi() {
+		errs := make([]error, 0, len(body.Messages))
+		for _, m := range body.Messages {
+			var b httpErrorBody
+			if json.Unmarshal(m, &b) != nil {
+				continue
+			}
+			if err := fromHTTPBody(&b, true, head, tail); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		node := flatten(errs)
+		node.truncated += body.Truncated
+		node.operation = body.Operation
+		node.tree = nested || body.Operation != ""
+		return derive(node, node.errors)
+	}
+
+	if body.Msg == "" && body.ID == "" {
+		return nil
+	}
+
+	op := make([]Options, 0, len(head)+len(tail)+5)
+	op = append(op, head...)
+	op = append(op, SetMsg(body.Msg), SetID(body.ID), SetOperation(body.Operation))
+	if et := ParseErrType(body.ErrorType); et != defaultErrType {
+		op = append(op, SetErrorType(et))
+	}
+	if body.RetryAfter != nil && *body.RetryAfter > 0 {
+		op = append(op, SetRetryAfter(time.Duration(*body.RetryAfter*float64(time.Second))))
+	}
+	op = append(op, tail...)
+	return NewWith(op...)
+}
+
+// ErrTypeFromHTTPStatus вернет тип ошибки, соответствующий HTTP-статусу.
+// Обратное преобразование к IErrType.HTTPStatusCode().
+// Для неизвестных статусов 5xx вернется Internal, для прочих -- Unknown.
+func ErrTypeFromHTTPStatus(status int) IErrType { //nolint:cyclop
+	switch status {
+	case http.StatusUnprocessableEntity:
+		return Validation
+	case http.StatusBadRequest:
+		return InputBody
+	case http.StatusConflict:
+		return Duplicate
+	case http.StatusUnauthorized:
+		return Unauthenticated
+	case http.StatusForbidden:
+		return Unauthorized
+	case http.StatusGone:
+		return Empty
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusInternalServerError:
+		return Internal
+	case http.StatusTooManyRequests:
+		return MaximumAttempts
+	case http.StatusPaymentRequired:
+		return SubscriptionExpired
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return DownstreamDependencyTimedout
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return Unavailable
+	}
+
+	if status >= http.StatusInternalServerError {
+		return Internal
+	}
+	return defaultErrType
+}
+
+// retryAfterSeconds вернет задержку в целых секундах с округлением вверх.
+func retryAfterSeconds(d time.Duration) int64 {
+	return int64((d + time.Second - 1) / time.Second)
+}
+
+// WriteHTTPProblem запишет ошибку err в HTTP-ответ w в формате Problem Details (RFC 7807):
+// заголовки (см. SetHTTPHeaders), статус (см. HTTPStatusCode) и тело (см. MarshalProblemJSON).
+// Для err == nil ничего не будет записано.
+// Для перевода сообщений на язык запроса используйте WriteHTTPProblemCtx.
+func WriteHTTPProblem(w http.ResponseWriter, err error) {
+	WriteHTTPProblemCtx(context.Background(), w, err)
+}
+
+// WriteHTTPProblemCtx как и WriteHTTPProblem запишет ошибку err в HTTP-ответ w,
+// но сообщения будут переведены локализатором из ctx (см. LocalizerFrom, LocalizerMiddleware)
+// с учетом псевдолокализации (см. WithPseudoLocalization).
+func WriteHTTPProblemCtx(ctx context.Context, w http.ResponseWriter, err error) {
+	if err == nil {
+		return
+	}
+
+	SetHTTPHeaders(w.Header(), err)
+	w.Header().Set("Content-Type", ContentTypeProblemJSON)
+
+	status, _ := HTTPStatusCode(err)
+	w.WriteHeader(status)
+
+	_ = MarshalProblemJSON{Localizer: localizerCtx(ctx, err)}.MarshalTo(err, w)
+}
+
+// LangQueryParam имя параметра запроса с языком, которое можно передать в LocalizerMiddleware.
+const LangQueryParam = "lang"
+
+// LocalizerMiddleware HTTP middleware, добавляющий в контекст запроса локализатор (см. WithLocalizer).
+// Локализатор создается функцией newLocalizer по языкам из заголовка Accept-Language.
+// Если заданы queryParams, язык из первого непустого параметра запроса с этими именами
+// имеет приоритет над Accept-Language.
+//
+//	mw := errors.LocalizerMiddleware(func(langs ...string) errors.Localizer {
+//		return i18n.NewLocalizer(bundle, langs...)
+//	}, errors.LangQueryParam)
+func LocalizerMiddleware(
+	newLocalizer func(langs ...string) Localizer, queryParams ...string,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			langs := make([]string, 0, 2)
+			if len(queryParams) > 0 {
+				query := r.URL.Query()
+				for _, name := range queryParams {
+					if lang := query.Get(name); lang != "" {
+						langs = append(langs, lang)
+						break
+					}
+				}
+			}
+			if accept := r.Header.Get("Accept-Language"); accept != "" {
+				langs = append(langs, accept)
+			}
+
+			ctx := WithLocalizer(r.Context(), newLocalizer(langs...))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package errors
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfterHeader(t *testing.T) {
+	v, ok := RetryAfterHeader(UnavailableErrWith(SetRetryAfter(1500 * time.Millisecond)))
+	require.True(t, ok)
+	require.Equal(t, "2", v)
+
+	at := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	v, ok = RetryAfterHeader(Combine(
+		UnavailableErrWith(SetRetryAfter(time.Second)),
+		MaximumAttemptsErrWith(SetRetryAt(at)),
+	))
+	require.True(t, ok)
+	require.Equal(t, at.Format(http.TimeFormat), v)
+
+	_, ok = RetryAfterHeader(ValidationErr("bad"))
+	require.False(t, ok)
+}
+
+func TestWriteHTTP(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteHTTP(w, MaximumAttemptsErrWith(SetMsg("slow down"), SetRetryAfter(30*time.Second)))
+
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "30", w.Header().Get(HeaderRetryAfter))
+	require.Contains(t, w.Body.String(), `"retry_after":30`)
+}
+
+func TestFromHTTPResponse(t *testing.T) {
+	response := func(status int, body string, header http.Header) *http.Response {
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		require.Nil(t, FromHTTPResponse(response(http.StatusOK, "", nil)))
+	})
+
+	t.Run("json body", func(t *testing.T) {
+		src := UnavailableErrWith(SetMsg("down"), SetID("id1"), SetRetryAfter(5*time.Second))
+		w := httptest.NewRecorder()
+		WriteHTTP(w, src)
+
+		err := FromHTTPResponse(response(w.Code, w.Body.String(), nil))
+		e, ok := err.(*Error) //nolint:errorlint
+		require.True(t, ok)
+		require.Equal(t, "down", e.Msg())
+		require.Equal(t, "id1", e.ID())
+		require.Equal(t, Unavailable, e.ErrorType())
+		require.Equal(t, 5*time.Second, e.RetryAfter())
+		require.True(t, IsRetryable(err))
+	})
+
+	t.Run("multierror body", func(t *testing.T) {
+		src := Combine(
+			NotFoundErrWith(SetMsg("no user"), SetID("id1")),
+			CombineTree("load", New("plain"), UnavailableErrWith(SetMsg("down"))),
+		)
+		w := httptest.NewRecorder()
+		WriteHTTP(w, src)
+
+		err := FromHTTPResponse(response(
+			w.Code, w.Body.String(),
+			http.Header{HeaderRetryAfter: []string{"3"}},
+		))
+		merr, ok := err.(Multierror) //nolint:errorlint
+		require.True(t, ok)
+		require.Len(t, merr.Errors(), 2)
+
+		e, ok := merr.Errors()[0].(*Error) //nolint:errorlint
+		require.True(t, ok)
+		require.Equal(t, "no user", e.Msg())
+		require.Equal(t, "id1", e.ID())
+		require.Equal(t, NotFound, e.ErrorType())
+		require.Equal(t, 3*time.Second, e.RetryAfter())
+
+		node, ok := merr.Errors()[1].(Multierror) //nolint:errorlint
+		require.True(t, ok)
+		require.Equal(t, "load", operationOf(node))
+		require.Len(t, node.Errors(), 2)
+		require.Equal(t, "plain", node.Errors()[0].(*Error).Msg()) //nolint:errorlint
+		require.Equal(t, Unavailable, Classify(node.Errors()[1]))
+	})
+
+	t.Run("header seconds", func(t *testing.T) {
+		err := FromHTTPResponse(response(
+			http.StatusTooManyRequests, "rate limited",
+			http.Header{HeaderRetryAfter: []string{"10"}},
+		))
+		require.Equal(t, MaximumAttempts, Classify(err))
+		require.Equal(t, "rate limited", err.(*Error).Msg()) //nolint:errorlint
+		require.Equal(t, 10*time.Second, RetryAfter(err))
+	})
+
+	t.Run("header date", func(t *testing.T) {
+		at := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+		err := FromHTTPResponse(response(
+			http.StatusServiceUnavailable, "",
+			http.Header{HeaderRetryAfter: []string{at.Format(http.TimeFormat)}},
+		))
+		require.Equal(t, Unavailable, Classify(err))
+		require.Equal(t, http.StatusText(http.StatusServiceUnavailable), err.(*Error).Msg()) //nolint:errorlint
+		require.True(t, at.Equal(err.(*Error).RetryAt()))                                    //nolint:errorlint
+	})
+}
+
+func TestParseErrType(t *testing.T) {
+	for _, et := range []IErrType{
+		Unknown, Internal, Validation, InputBody, Duplicate, Unauthenticated, Unauthorized,
+		Empty, NotFound, MaximumAttempts, SubscriptionExpired, DownstreamDependencyTimedout, Unavailable,
+	} {
+		require.Equal(t, et, ParseErrType(et.String()), et.String())
+	}
+	require.Equal(t, Unknown, ParseErrType("Teapot"))
+}
+
+func TestErrTypeFromHTTPStatus(t *testing.T) {
+	for _, et := range []IErrType{
+		Internal, Validation, InputBody, Duplicate, Unauthenticated, Unauthorized,
+		Empty, NotFound, MaximumAttempts, SubscriptionExpired, DownstreamDependencyTimedout, Unavailable,
+	} {
+		require.Equal(t, et, ErrTypeFromHTTPStatus(et.HTTPStatusCode()), et.String())
+	}
+	require.Equal(t, Internal, ErrTypeFromHTTPStatus(599))
+	require.Equal(t, Unknown, ErrTypeFromHTTPStatus(http.StatusTeapot))
+}
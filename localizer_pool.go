@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"sync"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// LocalizerPool кэш локализаторов *i18n.Bundle по согласованному языку.
+// Языки запроса (например, значение заголовка Accept-Language) сопоставляются
+// с языками bundle с помощью language.Matcher, для каждого найденного языка
+// создается и кэшируется один локализатор.
+//
+// Метод Localizer может быть использован в LocalizerMiddleware:
+//
+//	pool := errors.NewLocalizerPool(bundle)
+//	mw := errors.LocalizerMiddleware(pool.Localizer)
+type LocalizerPool struct {
+	bundle *i18n.Bundle
+
+	mu         sync.RWMutex
+	tags       []language.Tag
+	matcher    language.Matcher
+	localizers map[language.Tag]Localizer
+}
+
+// NewLocalizerPool конструктор *LocalizerPool.
+// * bundle *i18n.Bundle -- bundle с сообщениями;
+// язык по-умолчанию bundle используется, если ни один из языков запроса не подошел.
+func NewLocalizerPool(bundle *i18n.Bundle) *LocalizerPool {
+	return &LocalizerPool{
+		bundle:     bundle,
+		localizers: make(map[language.Tag]Localizer),
+	}
+}
+
+// Localizer вернет локализатор для языков langs.
+// Каждый элемент langs -- значение в формате Accept-Language ("ru-RU,ru;q=0.9,en;q=0.8")
+// или отдельный языковой тег; элементы перечисляются в порядке убывания приоритета.
+// Некорректные значения пропускаются.
+func (p *LocalizerPool) Localizer(langs ...string) Localizer {
+	tag := p.Match(langs...)
+
+	p.mu.RLock()
+	l, ok := p.localizers[tag]
+	p.mu.RUnlock()
+	if ok {
+		return l
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if l, ok := p.localizers[tag]; ok {
+		return l
+	}
+	l = i18n.NewLocalizer(p.bundle, tag.String())
+	p.localizers[tag] = l
+	return l
+}
+
+// Match вернет язык bundle, наиболее подходящий для языков langs (см. Localizer).
+// Если в bundle нет ни одного языка, вернется language.Und.
+func (p *LocalizerPool) Match(langs ...string) language.Tag {
+	desired := make([]language.Tag, 0, len(langs))
+	for _, lang := range langs {
+		tags, _, err := language.ParseAcceptLanguage(lang)
+		if err != nil {
+			continue
+		}
+		desired = append(desired, tags...)
+	}
+
+	tags, matcher := p.languages()
+	if len(tags) == 0 {
+		return language.Und
+	}
+	_, idx, confidence := matcher.Match(desired...)
+	if confidence == language.No || idx >= len(tags) {
+		return tags[0]
+	}
+	return tags[idx]
+}
+
+// languages вернет языки bundle и language.Matcher для них.
+// Если в bundle были добавлены языки, matcher будет пересоздан, а кэш локализаторов очищен.
+func (p *LocalizerPool) languages() ([]language.Tag, language.Matcher) {
+	bundleTags := p.bundle.LanguageTags()
+
+	p.mu.RLock()
+	tags, matcher := p.tags, p.matcher
+	p.mu.RUnlock()
+	if matcher != nil && len(tags) == len(bundleTags) {
+		return tags, matcher
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.matcher == nil || len(p.tags) != len(bundleTags) {
+		p.tags = append([]language.Tag(nil), bundleTags...)
+		p.matcher = language.NewMatcher(p.tags)
+		p.localizers = make(map[language.Tag]Localizer)
+	}
+	return p.tags, p.matcher
+}
@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"testing"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestLocalizerPool(t *testing.T) {
+	bundle := testBundle()
+	pool := NewLocalizerPool(bundle)
+
+	tests := []struct {
+		name  string
+		langs []string
+		want  language.Tag
+	}{
+		{"empty", nil, language.English},
+		{"accept language", []string{"ru-RU,ru;q=0.9,en;q=0.8"}, language.Russian},
+		{"quality", []string{"en;q=0.5,ru;q=0.9"}, language.Russian},
+		{"priority", []string{"en", "ru"}, language.English},
+		{"unknown", []string{"de"}, language.English},
+		{"invalid", []string{"!!!", "ru"}, language.Russian},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, pool.Match(tt.langs...))
+		})
+	}
+
+	l1 := pool.Localizer("ru-RU")
+	l2 := pool.Localizer("ru;q=0.8")
+	require.Same(t, l1, l2)
+
+	msg, err := Translate(NewWith(SetID("ErrHello")), l1, nil)
+	require.NoError(t, err)
+	require.Equal(t, "привет", msg)
+
+	// добавлен новый язык
+	bundle.MustAddMessages(language.German, &i18n.Message{ID: "ErrHello", Other: "hallo"})
+	require.Equal(t, language.German, pool.Match("de-DE"))
+	require.NotSame(t, l1, pool.Localizer("ru"))
+}
+
+func TestLocalizerPoolEmptyBundle(t *testing.T) {
+	pool := NewLocalizerPool(&i18n.Bundle{})
+	require.Equal(t, language.Und, pool.Match("ru"))
+	require.NotNil(t, pool.Localizer("ru"))
+}
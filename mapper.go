@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"regexp"
+)
+
+// MapRule правило Mapper: если в дереве ошибок найдена ошибка, подходящая под Match,
+// она будет отображена в Template.
+type MapRule struct {
+	Match    Matcher
+	Template *Error
+}
+
+// MapIs правило для ошибки target, аналогично errors.Is.
+func MapIs(target error, tmpl *Error) MapRule {
+	return MapRule{Match: MatchErr(target), Template: tmpl}
+}
+
+// MapAs правило для ошибки, соответствующей типу target, аналогично errors.As.
+// См. MatchAs.
+func MapAs(target interface{}, tmpl *Error) MapRule {
+	return MapRule{Match: MatchAs(target), Template: tmpl}
+}
+
+// MapFunc правило для ошибки, для которой fn вернет true.
+func MapFunc(fn func(error) bool, tmpl *Error) MapRule {
+	return MapRule{Match: fn, Template: tmpl}
+}
+
+// MapMsg правило для ошибки, сообщение которой соответствует регулярному выражению re.
+func MapMsg(re *regexp.Regexp, tmpl *Error) MapRule {
+	return MapRule{Match: MatchMsg(re), Template: tmpl}
+}
+
+// Mapper отображает сторонние ошибки в *Error по таблице правил.
+type Mapper struct {
+	rules    []MapRule
+	fallback *Error
+}
+
+// NewMapper конструктор *Mapper.
+// * fallback *Error -- шаблон, который будет использован, если ни одно правило не подошло.
+// Может быть nil.
+// * rules ...MapRule -- правила в порядке убывания приоритета.
+func NewMapper(fallback *Error, rules ...MapRule) *Mapper {
+	return &Mapper{
+		rules:    rules,
+		fallback: fallback,
+	}
+}
+
+// Map отобразит ошибку err в шаблон первого подходящего правила.
+// Вернется цепочка, в которой первой будет копия шаблона с операцией operation,
+// а второй -- исходная ошибка err.
+// Если operation == "", операция шаблона не изменяется.
+//
+// Если ни одно правило не подошло, то:
+// * если err уже содержит *Error или fallback == nil, err вернется без изменений;
+// * иначе будет использован шаблон fallback.
+// Для err == nil, вернется nil.
+func (m *Mapper) Map(err error, operation string) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, r := range m.rules {
+		if Contains(err, r.Match) {
+			return wrapTemplate(r.Template, operation, err)
+		}
+	}
+
+	if m.fallback == nil || Contains(err, isError) {
+		return err
+	}
+
+	return wrapTemplate(m.fallback, operation, err)
+}
+
+func wrapTemplate(tmpl *Error, operation string, err error) error {
+	if operation != "" {
+		tmpl = tmpl.WithOptions(SetOperation(operation))
+	}
+	return Wrap(tmpl, err)
+}
+
+func isError(e error) bool {
+	_, ok := e.(*Error) //nolint:errorlint
+	return ok
+}
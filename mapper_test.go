@@ -0,0 +1,88 @@
+package errors
+
+import (
+	origerrors "errors"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mapperTestErr struct {
+	code int
+}
+
+func (e *mapperTestErr) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+func TestMapper(t *testing.T) {
+	errRecordNotFound := origerrors.New("record not found")
+
+	eNotFound := NotFoundErrWith(SetID("ErrDBNotFound"), SetMsg("not found"))
+	eDuplicate := DuplicateErrWith(SetID("ErrDBDuplicate"), SetMsg("duplicate"))
+	eCode := IternalErrWith(SetID("ErrCode"), SetMsg("code"))
+	eTimeout := DownstreamDependencyTimedoutErrWith(SetID("ErrTimeout"), SetMsg("timeout"))
+	eInternal := IternalErrWith(SetID("ErrDBInternal"), SetMsg("internal"))
+
+	m := NewMapper(
+		eInternal,
+		MapIs(errRecordNotFound, eNotFound),
+		MapMsg(regexp.MustCompile(`UNIQUE constraint failed`), eDuplicate),
+		MapAs(new(*mapperTestErr), eCode),
+		MapFunc(os.IsTimeout, eTimeout),
+	)
+
+	tests := []struct {
+		name string
+		err  error
+		want *Error
+	}{
+		{
+			name: "is",
+			err:  fmt.Errorf("query: %w", errRecordNotFound),
+			want: eNotFound,
+		},
+		{
+			name: "regexp",
+			err:  origerrors.New("UNIQUE constraint failed: users.email"),
+			want: eDuplicate,
+		},
+		{
+			name: "as",
+			err:  fmt.Errorf("query: %w", &mapperTestErr{code: 1}),
+			want: eCode,
+		},
+		{
+			name: "func",
+			err:  os.ErrDeadlineExceeded,
+			want: eTimeout,
+		},
+		{
+			name: "fallback",
+			err:  origerrors.New("unknown"),
+			want: eInternal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.Map(tt.err, "storage.GetUser")
+
+			merr, ok := got.(Multierror) //nolint:errorlint
+			require.True(t, ok)
+			require.Equal(t, []error{tt.want.WithOptions(SetOperation("storage.GetUser")), tt.err}, merr.Errors())
+			require.True(t, ContainsByID(got, tt.want.ID()))
+			require.True(t, ContainsByErr(got, tt.err))
+		})
+	}
+
+	require.Nil(t, m.Map(nil, "op"))
+
+	typed := ValidationErr("validation")
+	require.Equal(t, typed, m.Map(typed, "op"))
+	require.Equal(t, eNotFound, FindByID(NewMapper(nil).Map(eNotFound, "op"), eNotFound.ID()))
+
+	plain := origerrors.New("plain")
+	require.Equal(t, plain, NewMapper(nil).Map(plain, "op"))
+}
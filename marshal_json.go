@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
@@ -14,17 +15,22 @@ var (
 	_ Marshaller = (*MarshalJSON)(nil)
 )
 
-type MarshalJSON struct{}
+// MarshalJSON маршалер ошибки в JSON.
+type MarshalJSON struct {
+	// Localizer локализатор для перевода сообщений *Error.
+	// Если не задан, переводятся только сообщения ошибок с собственным локализатором (см. SetLocalizer).
+	Localizer Localizer
+}
 
-func (MarshalJSON) MarshalTo(i interface{}, dst io.Writer) error {
+func (m MarshalJSON) MarshalTo(i interface{}, dst io.Writer) error {
 	switch t := i.(type) { //nolint:errorlint
 	case nil:
 		_, _ = io.WriteString(dst, "null")
 		return nil
 	case interface{ Errors() []error }:
-		jsonMultierrFormat(dst, t.Errors())
+		jsonMultierrFormat(dst, t, m.Localizer)
 	case error:
-		jsonFormat(dst, t)
+		jsonFormat(dst, t, m.Localizer)
 	}
 	return nil
 }
@@ -42,7 +48,12 @@ func (m *MarshalJSON) Marshal(i interface{}) ([]byte, error) {
 	return data, nil
 }
 
-func jsonFormat(buf io.Writer, e error) { //nolint:funlen
+func jsonFormat(buf io.Writer, e error, l Localizer) { //nolint:funlen
+	if node, ok := treeNode(e); ok {
+		jsonMultierrFormat(buf, node, l)
+		return
+	}
+
 	switch t := e.(type) { //nolint:errorlint
 	case *Error:
 		_, _ = io.WriteString(buf, "{")
@@ -94,10 +105,28 @@ func jsonFormat(buf io.Writer, e error) { //nolint:funlen
 		}
 		_, _ = io.WriteString(buf, ",")
 
+		// RetryAfter
+		if d := t.RetryAfter(); d > 0 {
+			_, _ = io.WriteString(buf, "\"retry_after\":")
+			_, _ = io.WriteString(buf, strconv.FormatInt(retryAfterSeconds(d), 10))
+			_, _ = io.WriteString(buf, ",")
+		}
+
+		// FieldViolations
+		if violations := t.FieldViolations(); len(violations) > 0 {
+			_, _ = io.WriteString(buf, "\"invalid_params\":")
+			loc := l
+			if loc == nil {
+				loc = t.Localizer()
+			}
+			jsonInvalidParams(buf, violations, loc)
+			_, _ = io.WriteString(buf, ",")
+		}
+
 		// Msg
 		_, _ = io.WriteString(buf, "\"msg\":")
 		_, _ = io.WriteString(buf, "\"")
-		_, _ = buf.Write(s2b(t.Msg()))
+		_, _ = buf.Write(s2b(localizedMsg(t, l, nil)))
 		_, _ = io.WriteString(buf, "\"")
 
 		_, _ = io.WriteString(buf, "}")
@@ -113,32 +142,79 @@ func jsonFormat(buf io.Writer, e error) { //nolint:funlen
 }
 
 // JSONMultierrFuncFormat функция форматирования вывода сообщения для multierr в виде JSON.
-func jsonMultierrFormat(w io.Writer, es []error) {
+// Вложенные узлы дерева ошибок (см. CombineTree) выводятся вложенными объектами.
+func jsonMultierrFormat(w io.Writer, merr interface{ Errors() []error }, loc Localizer) {
+	es := merr.Errors()
+	truncated := truncatedCount(merr)
 	l := len(es)
-	if l == 0 {
+	if l == 0 && truncated == 0 {
 		_, _ = io.WriteString(w, "null")
 		return
 	}
 
 	_, _ = io.WriteString(w, "{")
 
+	if op := operationOf(merr); op != "" {
+		_, _ = io.WriteString(w, "\"operation\":")
+		_, _ = io.WriteString(w, "\"")
+		_, _ = w.Write(s2b(op))
+		_, _ = io.WriteString(w, "\",")
+	}
+
 	_, _ = io.WriteString(w, "\"count\":")
 	_, _ = io.WriteString(w, strconv.Itoa(l))
 	_, _ = io.WriteString(w, ",")
 
+	if truncated > 0 {
+		_, _ = io.WriteString(w, "\"truncated\":")
+		_, _ = io.WriteString(w, strconv.Itoa(truncated))
+		_, _ = io.WriteString(w, ",")
+	}
+
 	_, _ = io.WriteString(w, "\"messages\":")
 	_, _ = io.WriteString(w, "[")
 	switch l {
+	case 0:
 	case 1:
-		jsonFormat(w, es[0])
+		jsonFormat(w, es[0], loc)
 	default:
-		jsonFormat(w, es[0])
+		jsonFormat(w, es[0], loc)
 		for _, e := range es[1:] {
 			_, _ = io.WriteString(w, ",")
-			jsonFormat(w, e)
+			jsonFormat(w, e, loc)
 		}
 	}
 	_, _ = io.WriteString(w, "]")
 
 	_, _ = io.WriteString(w, "}")
 }
+
+// jsonInvalidParams выведет нарушения валидации полей в виде массива invalid_params (RFC 7807).
+// Если задан локализатор l, сообщения о нарушениях будут переведены (см. FieldViolation.Translate).
+func jsonInvalidParams(w io.Writer, violations []FieldViolation, l Localizer) {
+	_, _ = io.WriteString(w, "[")
+	for i, v := range violations {
+		if i > 0 {
+			_, _ = w.Write(_listSeparator)
+		}
+		_, _ = io.WriteString(w, "{\"name\":")
+		writeJSONString(w, v.Field)
+		if v.Code != "" {
+			_, _ = io.WriteString(w, ",\"code\":")
+			writeJSONString(w, v.Code)
+		}
+		reason := v.Message
+		if l != nil {
+			reason, _ = v.Translate(l)
+		}
+		_, _ = io.WriteString(w, ",\"reason\":")
+		writeJSONString(w, reason)
+		_, _ = io.WriteString(w, "}")
+	}
+	_, _ = io.WriteString(w, "]")
+}
+
+func writeJSONString(w io.Writer, s string) {
+	data, _ := json.Marshal(s)
+	_, _ = w.Write(data)
+}
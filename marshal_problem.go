@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// ContentTypeProblemJSON тип содержимого ответа в формате Problem Details (RFC 7807).
+const ContentTypeProblemJSON = "application/problem+json"
+
+var _ Marshaller = (*MarshalProblemJSON)(nil)
+
+// MarshalProblemJSON маршалер ошибки в формат Problem Details for HTTP APIs (RFC 7807).
+// Нарушения валидации полей всех *Error дерева ошибок выводятся в invalid_params.
+type MarshalProblemJSON struct {
+	// Type URI типа проблемы. По-умолчанию "about:blank".
+	Type string
+	// Localizer локализатор для перевода detail и invalid_params.
+	// Если не задан, переводятся только сообщения ошибок с собственным локализатором (см. SetLocalizer).
+	Localizer Localizer
+}
+
+type problemParam struct {
+	Name   string `json:"name"`
+	Code   string `json:"code,omitempty"`
+	Reason string `json:"reason"`
+}
+
+type problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	ID            string         `json:"id,omitempty"`
+	Operation     string         `json:"operation,omitempty"`
+	ErrorType     string         `json:"error_type"`
+	RetryAfter    int64          `json:"retry_after,omitempty"`
+	InvalidParams []problemParam `json:"invalid_params,omitempty"`
+}
+
+func (m MarshalProblemJSON) MarshalTo(i interface{}, dst io.Writer) error {
+	err, ok := i.(error)
+	if !ok || err == nil {
+		_, _ = io.WriteString(dst, "null")
+		return nil
+	}
+
+	status, _ := HTTPStatusCode(err)
+	p := problem{
+		Type:   m.Type,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+	}
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+
+	et, _ := GetErrType(err)
+	p.ErrorType = et.String()
+
+	if e := primaryErr(err); e != nil {
+		p.Detail = localizedMsg(e, m.Localizer, nil)
+		p.ID = e.ID()
+		p.Operation = e.Operation()
+	}
+
+	if d := RetryAfter(err); d > 0 {
+		p.RetryAfter = retryAfterSeconds(d)
+	}
+
+	violations := CollectFieldViolations(err)
+	if m.Localizer != nil {
+		violations = TranslateFieldViolations(err, m.Localizer)
+	}
+	for _, v := range violations {
+		p.InvalidParams = append(p.InvalidParams, problemParam{
+			Name:   v.Field,
+			Code:   v.Code,
+			Reason: v.Message,
+		})
+	}
+
+	return json.NewEncoder(dst).Encode(&p)
+}
+
+func (m MarshalProblemJSON) Marshal(i interface{}) ([]byte, error) {
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	if err := m.MarshalTo(i, buf); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), buf.Bytes()...), nil
+}
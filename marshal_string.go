@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 
 	"github.com/valyala/bytebufferpool"
 )
@@ -16,6 +17,9 @@ var (
 	_multilineIndent    = []byte("\t#")                            //nolint:gochecknoglobals
 	_multilineSeparator = []byte{'\n'}                             //nolint:gochecknoglobals
 	_multilinePrefix    = []byte("the following errors occurred:") //nolint:gochecknoglobals
+	_truncatedPrefix    = []byte("… and ")                         //nolint:gochecknoglobals
+	_truncatedSuffix    = []byte(" more errors")                   //nolint:gochecknoglobals
+	_truncatedSuffixOne = []byte(" more error")                    //nolint:gochecknoglobals
 
 	_separator = []byte{' '} //nolint:gochecknoglobals
 
@@ -28,16 +32,21 @@ var (
 
 var _ Marshaller = (*MarshalString)(nil)
 
-type MarshalString struct{}
+// MarshalString маршалер ошибки в текстовый вид.
+type MarshalString struct {
+	// Localizer локализатор для перевода сообщений *Error.
+	// Если не задан, переводятся только сообщения ошибок с собственным локализатором (см. SetLocalizer).
+	Localizer Localizer
+}
 
 func (m *MarshalString) MarshalTo(i interface{}, dst io.Writer) error {
 	switch t := i.(type) { //nolint:errorlint
 	case nil:
 		return nil
 	case interface{ Errors() []error }: // multiError
-		stringMultierrFormat(dst, t.Errors())
+		stringMultierrFormat(dst, t, 0, m.Localizer, nil)
 	case error: // one
-		stringFormat(dst, t)
+		stringFormat(dst, t, m.Localizer, nil)
 	}
 
 	return nil
@@ -58,21 +67,82 @@ func (m *MarshalString) Marshal(i interface{}) ([]byte, error) {
 
 //
 
-func stringMultierrFormat(w io.Writer, es []error) {
-	_, _ = w.Write(_multilinePrefix)
+// stringMultierrFormat выведет список ошибок merr с отступом depth.
+// Вложенные узлы дерева ошибок (см. CombineTree) выводятся с увеличенным отступом.
+// tctx -- контекст перевода сообщений *Error (см. Translate), может быть nil.
+func stringMultierrFormat(
+	w io.Writer, merr interface{ Errors() []error }, depth int, l Localizer, tctx *TranslateContext,
+) {
+	if op := operationOf(merr); op != "" {
+		_, _ = w.Write(_opDelimiterLeft)
+		_, _ = w.Write(s2b(op))
+		_, _ = w.Write(_opDelimiterRight)
+		_, _ = w.Write(_separator)
+	}
+	if l != nil {
+		_, _ = io.WriteString(w, localizeMessage(l, MultierrHeaderMessage, nil, nil))
+	} else {
+		_, _ = w.Write(_multilinePrefix)
+	}
 	_, _ = w.Write(_multilineSeparator)
-	for i, err := range es {
+	for i, err := range merr.Errors() {
 		if err == nil {
 			continue
 		}
+		writeIndent(w, depth)
 		_, _ = w.Write(_multilineIndent)
 		_, _ = w.Write([]byte(strconv.Itoa(i + 1)))
 		_, _ = w.Write([]byte(" "))
-		stringFormat(w, err)
+		if node, ok := treeNode(err); ok {
+			stringMultierrFormat(w, node, depth+1, l, tctx)
+			continue
+		}
+		stringFormat(w, err, l, tctx)
+		_, _ = w.Write(_multilineSeparator)
+	}
+
+	if truncated := truncatedCount(merr); truncated > 0 {
+		writeIndent(w, depth)
+		_, _ = w.Write(_multilineIndent[:1])
+		if l != nil {
+			_, _ = io.WriteString(w, localizeMessage(l, MultierrTruncatedMessage,
+				map[string]interface{}{"Count": groupDigits(truncated)}, truncated))
+		} else {
+			_, _ = w.Write(_truncatedPrefix)
+			_, _ = io.WriteString(w, groupDigits(truncated))
+			if truncated == 1 {
+				_, _ = w.Write(_truncatedSuffixOne)
+			} else {
+				_, _ = w.Write(_truncatedSuffix)
+			}
+		}
 		_, _ = w.Write(_multilineSeparator)
 	}
 }
 
+func writeIndent(w io.Writer, depth int) {
+	for i := 0; i < depth; i++ {
+		_, _ = w.Write(_multilineIndent[:1])
+	}
+}
+
+// groupDigits вернет число n с разделением разрядов пробелом: 99900 -> "99 900".
+func groupDigits(n int) string {
+	s := strconv.Itoa(n)
+	if len(s) <= 3 {
+		return s
+	}
+
+	out := make([]byte, 0, len(s)+len(s)/3)
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			out = append(out, ' ')
+		}
+		out = append(out, s[i])
+	}
+	return string(out)
+}
+
 func contextInfoFormat(w io.Writer, ctxi CtxKV, useDelimiter bool) {
 	if len(ctxi) < 1 {
 		return
@@ -100,15 +170,23 @@ func contextInfoFormat(w io.Writer, ctxi CtxKV, useDelimiter bool) {
 	}
 }
 
-func stringFormat(w io.Writer, e error) {
+func stringFormat(w io.Writer, e error, l Localizer, tctx *TranslateContext) {
 	switch t := e.(type) { //nolint:errorlint
 	case *Error:
 		// id do not write
 
 		// err type
 		if et := t.ErrorType(); et != nil && et.Number() > 0 {
+			loc := l
+			if loc == nil {
+				loc = t.Localizer()
+			}
 			_, _ = w.Write(_errTypeDelimerLeft)
-			_, _ = io.WriteString(w, t.ErrorType().String())
+			if loc != nil {
+				_, _ = io.WriteString(w, TranslateErrType(et, loc))
+			} else {
+				_, _ = io.WriteString(w, et.String())
+			}
 			_, _ = w.Write(_errTypeDelimerRight)
 			_, _ = w.Write(_separator)
 		}
@@ -125,9 +203,36 @@ func stringFormat(w io.Writer, e error) {
 		contextInfoFormat(w, t.ContextInfo(), true)
 
 		// msg
-		_, _ = w.Write(s2b(t.Msg()))
+		_, _ = w.Write(s2b(localizedMsg(t, l, tctx)))
 
 	default:
-		_, _ = io.WriteString(w, t.Error())
+		if l == nil {
+			_, _ = io.WriteString(w, t.Error())
+			return
+		}
+		wrappedFormat(w, t, l, tctx)
+	}
+}
+
+// wrappedFormat выведет текст ошибки-обертки e (например, fmt.Errorf("...: %w", err)),
+// в котором текст первой *Error цепочки будет заменен ее переводом (см. stringFormat).
+// Если *Error в цепочке нет или ее текст не входит в текст обертки, выводится e.Error().
+func wrappedFormat(w io.Writer, e error, l Localizer, tctx *TranslateContext) {
+	text := e.Error()
+
+	inner, ok := Find(e, isError).(*Error) //nolint:errorlint
+	if !ok {
+		_, _ = io.WriteString(w, text)
+		return
 	}
+	innerText := inner.Error()
+	i := strings.Index(text, innerText)
+	if i < 0 {
+		_, _ = io.WriteString(w, text)
+		return
+	}
+
+	_, _ = io.WriteString(w, text[:i])
+	stringFormat(w, inner, l, tctx)
+	_, _ = io.WriteString(w, text[i+len(innerText):])
 }
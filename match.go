@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// Matcher проверка ошибки, используется при поиске в дереве ошибок.
+type Matcher func(error) bool
+
+// MatchID вернет Matcher для *Error с указанным ID.
+func MatchID(id string) Matcher {
+	return func(e error) bool {
+		ee, ok := e.(*Error) //nolint:errorlint
+		return ok && ee.ID() == id
+	}
+}
+
+// MatchErr вернет Matcher для ошибки target (например, sentinel-ошибки).
+func MatchErr(target error) Matcher {
+	return func(e error) bool {
+		return isTarget(e, target)
+	}
+}
+
+// MatchType вернет Matcher для *Error с указанным типом.
+func MatchType(t IErrType) Matcher {
+	return func(e error) bool {
+		_, ok := e.(*Error) //nolint:errorlint
+		et, _ := GetErrType(e)
+		return ok && et == t
+	}
+}
+
+// MatchAs вернет Matcher для ошибки, которая может быть присвоена в target,
+// аналогично errors.As. target -- указатель на переменную нужного типа,
+// например new(*net.OpError). Сам target не изменяется.
+func MatchAs(target interface{}) Matcher {
+	typ := reflect.TypeOf(target)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		panic("errors: target must be a non-nil pointer")
+	}
+	typ = typ.Elem()
+
+	return func(e error) bool {
+		if reflect.TypeOf(e).AssignableTo(typ) {
+			return true
+		}
+		if x, ok := e.(interface{ As(interface{}) bool }); ok { //nolint:errorlint
+			return x.As(reflect.New(typ).Interface())
+		}
+		return false
+	}
+}
+
+// MatchMsg вернет Matcher для ошибки, сообщение которой соответствует re.
+func MatchMsg(re *regexp.Regexp) Matcher {
+	return func(e error) bool {
+		return re.MatchString(e.Error())
+	}
+}
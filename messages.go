@@ -0,0 +1,109 @@
+package errors
+
+import (
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// ID сообщений перевода, используемых маршалерами.
+const (
+	// MultierrHeaderID ID перевода заголовка списка ошибок Multierror.
+	MultierrHeaderID = "ErrorsMultierrHeader"
+	// MultierrTruncatedID ID перевода строки о числе ошибок, не вошедших в цепочку.
+	// Данные шаблона: Count -- число ошибок; используется множественное число.
+	MultierrTruncatedID = "ErrorsMultierrTruncated"
+
+	errTypeMessagePrefix = "ErrorsType"
+)
+
+// Сообщения по-умолчанию (en).
+var (
+	MultierrHeaderMessage = &i18n.Message{ //nolint:gochecknoglobals
+		ID:          MultierrHeaderID,
+		Description: "Заголовок списка ошибок",
+		Other:       string(_multilinePrefix),
+	}
+	MultierrTruncatedMessage = &i18n.Message{ //nolint:gochecknoglobals
+		ID:          MultierrTruncatedID,
+		Description: "Число ошибок, не вошедших в список",
+		One:         "… and {{.Count}} more error",
+		Other:       "… and {{.Count}} more errors",
+	}
+)
+
+// ErrTypeMessageID вернет ID перевода имени типа ошибки, например "ErrorsTypeNotFound".
+func ErrTypeMessageID(et IErrType) string {
+	if et == nil {
+		et = defaultErrType
+	}
+	return errTypeMessagePrefix + et.String()
+}
+
+// ErrTypeMessage вернет сообщение по-умолчанию (en) для имени типа ошибки.
+func ErrTypeMessage(et IErrType) *i18n.Message {
+	if et == nil {
+		et = defaultErrType
+	}
+	return &i18n.Message{
+		ID:          ErrTypeMessageID(et),
+		Description: "Имя типа ошибки " + et.String(),
+		Other:       et.String(),
+	}
+}
+
+// DefaultMessages вернет сообщения по-умолчанию (en) для имен типов ошибок
+// и заголовков Multierror. Могут быть добавлены в bundle:
+//
+//	bundle.MustAddMessages(language.English, errors.DefaultMessages()...)
+func DefaultMessages() []*i18n.Message {
+	msgs := []*i18n.Message{MultierrHeaderMessage, MultierrTruncatedMessage}
+	for _, et := range errTypes() {
+		msgs = append(msgs, ErrTypeMessage(et))
+	}
+	return msgs
+}
+
+// TranslateErrType вернет перевод имени типа ошибки et с помощью локализатора l.
+// Если l не задан или перевод не выполнен, вернется et.String().
+func TranslateErrType(et IErrType, l Localizer) string {
+	if et == nil {
+		et = defaultErrType
+	}
+	return localizeMessage(l, ErrTypeMessage(et), nil, nil)
+}
+
+// errTypes вернет все типы ошибок.
+func errTypes() []IErrType {
+	types := make([]IErrType, 0, len(_errType_index)-1)
+	for i := 1; i < len(_errType_index); i++ {
+		types = append(types, errType(i))
+	}
+	return types
+}
+
+// localizeMessage вернет перевод сообщения msg с помощью l.
+// Если l не задан или перевод не выполнен, вернется msg.Other с подстановкой данных шаблона.
+func localizeMessage(l Localizer, msg *i18n.Message, data, count interface{}) string {
+	conf := &i18n.LocalizeConfig{
+		DefaultMessage: msg,
+		TemplateData:   data,
+		PluralCount:    count,
+	}
+
+	if l != nil {
+		// при отсутствии перевода go-i18n вернет сообщение по-умолчанию вместе с ошибкой
+		if s, _ := l.Localize(conf); s != "" {
+			return s
+		}
+	}
+
+	s, err := defaultMessagesLocalizer.Localize(conf)
+	if err != nil {
+		return msg.Other
+	}
+	return s
+}
+
+// defaultMessagesLocalizer локализатор с пустым bundle для подстановки данных в сообщения по-умолчанию.
+// Bundle не изменяется, поэтому локализатор безопасно использовать конкурентно.
+var defaultMessagesLocalizer = i18n.NewLocalizer(i18n.NewBundle(language.English)) //nolint:gochecknoglobals
@@ -0,0 +1,154 @@
+package errors
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrMessageFileLang язык в имени файла сообщений не является корректным языковым тегом.
+	ErrMessageFileLang = NewWith(
+		SetID("ErrMessageFileLang"), SetMsg("invalid message file language"), SetErrorType(InputBody))
+	// ErrMessageFileParse файл сообщений не удалось разобрать.
+	ErrMessageFileParse = NewWith(
+		SetID("ErrMessageFileParse"), SetMsg("message file parse failed"), SetErrorType(InputBody))
+)
+
+// MessageFilePrefix префикс имен файлов сообщений: active.<язык>.<формат>.
+const MessageFilePrefix = "active."
+
+// LoadReport отчет о загрузке файлов сообщений (см. LoadMessagesFS).
+type LoadReport struct {
+	// Files загруженные файлы сообщений в порядке загрузки.
+	Files []*i18n.MessageFile
+	// Languages языки загруженных сообщений, упорядоченные по имени.
+	Languages []language.Tag
+	// Messages число загруженных сообщений по языкам.
+	Messages map[language.Tag]int
+}
+
+// LoadMessagesFS загрузит в bundle все файлы сообщений active.<язык>.{toml,json,yaml,yml}
+// из fsys (например, embed.FS), включая вложенные каталоги.
+// Для bundle будут зарегистрированы функции разбора toml и yaml.
+// Язык каждого файла должен быть корректным языковым тегом (BCP 47).
+//
+// Вернется пул локализаторов bundle (см. LocalizerPool) и отчет о загрузке.
+// При ошибках загрузки дополнительно вернется Multierror со всеми ошибками
+// (копии ErrMessageFileLang, ErrMessageFileParse в цепочках, см. ContainsByID),
+// а пул и отчет будут содержать только успешно загруженные файлы.
+func LoadMessagesFS(bundle *i18n.Bundle, fsys fs.FS) (*LocalizerPool, *LoadReport, error) {
+	var (
+		files []messageFile
+		errs  []error
+	)
+	walkErr := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := messageFileLang(path.Base(p)); !ok {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			errs = append(errs, messageFileErr(ErrMessageFileParse, p, err))
+			return nil
+		}
+		files = append(files, messageFile{path: p, data: data})
+
+		return nil
+	})
+	if walkErr != nil {
+		errs = append(errs, walkErr)
+	}
+
+	return loadMessages(bundle, files, errs)
+}
+
+// messageFile прочитанный файл сообщений.
+type messageFile struct {
+	path string
+	data []byte
+}
+
+// loadMessages загрузит в bundle прочитанные файлы сообщений files (см. LoadMessagesFS).
+// errs -- ошибки, возникшие до загрузки (например, при чтении файлов).
+func loadMessages(bundle *i18n.Bundle, files []messageFile, errs []error) (*LocalizerPool, *LoadReport, error) {
+	bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
+	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
+	bundle.RegisterUnmarshalFunc("yml", yaml.Unmarshal)
+
+	report := &LoadReport{
+		Messages: make(map[language.Tag]int),
+	}
+
+	for _, f := range files {
+		lang, _ := messageFileLang(path.Base(f.path))
+		if _, err := language.Parse(lang); err != nil {
+			errs = append(errs, messageFileErr(ErrMessageFileLang, f.path, err))
+			continue
+		}
+
+		mf, err := bundle.ParseMessageFileBytes(f.data, f.path)
+		if err != nil {
+			errs = append(errs, messageFileErr(ErrMessageFileParse, f.path, err))
+			continue
+		}
+		report.add(mf)
+	}
+
+	report.sort()
+
+	return NewLocalizerPool(bundle), report, Combine(errs...)
+}
+
+// messageFileLang вернет язык из имени файла сообщений active.<язык>.<формат>.
+func messageFileLang(name string) (string, bool) {
+	if !strings.HasPrefix(name, MessageFilePrefix) {
+		return "", false
+	}
+
+	ext := path.Ext(name)
+	switch ext {
+	case ".toml", ".json", ".yaml", ".yml":
+	default:
+		return "", false
+	}
+
+	lang := strings.TrimSuffix(strings.TrimPrefix(name, MessageFilePrefix), ext)
+	return lang, lang != ""
+}
+
+func messageFileErr(e *Error, file string, err error) error {
+	return Wrap(
+		e.WithOptions(
+			SetOperation("LoadMessagesFS"),
+			AppendContextInfo("file", file),
+		),
+		err,
+	)
+}
+
+func (r *LoadReport) add(mf *i18n.MessageFile) {
+	r.Files = append(r.Files, mf)
+	if _, ok := r.Messages[mf.Tag]; !ok {
+		r.Languages = append(r.Languages, mf.Tag)
+	}
+	r.Messages[mf.Tag] += len(mf.Messages)
+}
+
+func (r *LoadReport) sort() {
+	sort.Slice(r.Languages, func(i, j int) bool {
+		return r.Languages[i].String() < r.Languages[j].String()
+	})
+}
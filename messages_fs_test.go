@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"testing"
+	"testing/fstest"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestLoadMessagesFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"active.ru.toml": {Data: []byte(`ErrHello = "привет"
+ErrBye = "пока"`)},
+		"locales/active.de.yaml":  {Data: []byte("ErrHello: hallo\n")},
+		"locales/active.fr.json":  {Data: []byte(`{"ErrHello": "bonjour"}`)},
+		"locales/active.en.yml":   {Data: []byte("ErrHello: hello\n")},
+		"locales/readme.toml":     {Data: []byte("ignored = 1")},
+		"locales/active.ru.jsonx": {Data: []byte("ignored")},
+	}
+
+	pool, report, err := LoadMessagesFS(i18n.NewBundle(language.English), fsys)
+	require.NoError(t, err)
+	require.NotNil(t, pool)
+
+	require.Len(t, report.Files, 4)
+	require.Equal(t, []language.Tag{language.German, language.English, language.French, language.Russian}, report.Languages)
+	require.Equal(t, 2, report.Messages[language.Russian])
+	require.Equal(t, 1, report.Messages[language.German])
+
+	for lang, want := range map[string]string{"ru": "привет", "de": "hallo", "fr": "bonjour", "en": "hello"} {
+		msg, err := Translate(NewWith(SetID("ErrHello")), pool.Localizer(lang), nil)
+		require.NoError(t, err)
+		require.Equal(t, want, msg)
+	}
+}
+
+func TestLoadMessagesFSErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"active.ru.toml":     {Data: []byte(`ErrHello = "привет"`)},
+		"active.xx_1.toml":   {Data: []byte(`ErrHello = "?"`)},
+		"active.de.yaml":     {Data: []byte("ErrHello: [")},
+		"active.fr.toml":     {Data: []byte(`ErrHello = "bonjour"`)},
+		"sub/active.es.toml": {Data: []byte(`ErrHello = "hola"`)},
+	}
+
+	pool, report, err := LoadMessagesFS(i18n.NewBundle(language.English), fsys)
+	require.True(t, ContainsByID(err, ErrMessageFileLang.ID()))
+	require.True(t, ContainsByID(err, ErrMessageFileParse.ID()))
+	require.Len(t, report.Files, 3)
+
+	// успешно загруженные файлы доступны в пуле
+	require.NotNil(t, pool)
+	msg, _ := Translate(NewWith(SetID("ErrHello")), pool.Localizer("fr"), nil)
+	require.Equal(t, "bonjour", msg)
+}
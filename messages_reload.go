@@ -0,0 +1,253 @@
+package errors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"path"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// ErrMessagesReload файлы сообщений не удалось перезагрузить (см. ReloadingLocalizer.Reload).
+var ErrMessagesReload = NewWith(
+	SetID("ErrMessagesReload"), SetMsg("messages reload failed"), SetErrorType(Internal))
+
+// MessagesVersion сведения о загруженной версии файлов сообщений.
+type MessagesVersion struct {
+	// Number порядковый номер версии, начиная с 1.
+	Number uint64
+	// Hash хэш содержимого файлов сообщений (sha256, hex).
+	Hash string
+	// LoadedAt время загрузки версии.
+	LoadedAt time.Time
+	// Report отчет о загрузке (см. LoadMessagesFS).
+	Report *LoadReport
+	// Err ошибка последней неудачной перезагрузки или nil.
+	Err error
+}
+
+// ReloadingLocalizer источник локализаторов, перезагружающий файлы сообщений
+// active.<язык>.{toml,json,yaml,yml} (см. LoadMessagesFS) при их изменении.
+//
+// Изменения определяются опросом (см. Reload, Watch): сначала по размеру и времени изменения файлов,
+// затем по хэшу содержимого. Новый bundle подменяется атомарно;
+// если файлы не удалось загрузить, продолжает использоваться предыдущая версия.
+//
+//	r, err := errors.NewReloadingLocalizer(os.DirFS("locales"), language.English)
+//	go r.Watch(ctx, 10*time.Second, func(err error) { errors.Log(err) })
+//	mw := errors.LocalizerMiddleware(r.Localizer)
+type ReloadingLocalizer struct {
+	fsys        fs.FS
+	defaultLang language.Tag
+
+	mu    sync.Mutex // сериализует Reload
+	stamp string     // размеры и время изменения файлов последней проверки
+
+	state atomic.Value // *reloadState
+}
+
+type reloadState struct {
+	pool    *LocalizerPool
+	version MessagesVersion
+}
+
+// NewReloadingLocalizer конструктор *ReloadingLocalizer.
+// * fsys fs.FS -- каталог с файлами сообщений, например os.DirFS(dir);
+// * defaultLang language.Tag -- язык по-умолчанию bundle.
+// Вернет ошибку, если файлы сообщений не удалось загрузить.
+func NewReloadingLocalizer(fsys fs.FS, defaultLang language.Tag) (*ReloadingLocalizer, error) {
+	r := &ReloadingLocalizer{
+		fsys:        fsys,
+		defaultLang: defaultLang,
+	}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Localizer вернет локализатор для языков langs (см. LocalizerPool.Localizer).
+// Локализатор всегда использует текущую версию сообщений,
+// поэтому его можно сохранить (например, в контексте запроса).
+func (r *ReloadingLocalizer) Localizer(langs ...string) Localizer {
+	return &reloadingLocalizer{r: r, langs: langs}
+}
+
+// Pool вернет пул локализаторов текущей версии сообщений.
+func (r *ReloadingLocalizer) Pool() *LocalizerPool {
+	return r.current().pool
+}
+
+// Version вернет сведения о текущей версии сообщений.
+func (r *ReloadingLocalizer) Version() MessagesVersion {
+	return r.current().version
+}
+
+// Reload проверит файлы сообщений и, если они изменились, загрузит их.
+// Вернет true, если была загружена новая версия.
+// При ошибке загрузки продолжает использоваться предыдущая версия,
+// а ошибка (*Error с ID ErrMessagesReload в цепочке) сохраняется в MessagesVersion.Err.
+// Файлы, которые не удалось разобрать, повторно не загружаются, пока они не изменятся.
+func (r *ReloadingLocalizer) Reload() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files, stamp, err := r.scan()
+	if err != nil {
+		return false, r.fail(err)
+	}
+
+	cur, _ := r.state.Load().(*reloadState)
+	if cur != nil && stamp == r.stamp {
+		return false, nil
+	}
+
+	data, hash, err := r.read(files)
+	if err != nil {
+		return false, r.fail(err)
+	}
+	if cur != nil && hash == cur.version.Hash {
+		// файлы возвращены к текущей версии
+		if cur.version.Err != nil {
+			next := *cur
+			next.version.Err = nil
+			r.state.Store(&next)
+		}
+		r.stamp = stamp
+		return false, nil
+	}
+
+	// загружается прочитанное содержимое, соответствующее hash
+	pool, report, err := loadMessages(i18n.NewBundle(r.defaultLang), data, nil)
+	if err != nil {
+		r.stamp = stamp
+		return false, r.fail(err)
+	}
+
+	var number uint64 = 1
+	if cur != nil {
+		number = cur.version.Number + 1
+	}
+	r.state.Store(&reloadState{
+		pool: pool,
+		version: MessagesVersion{
+			Number:   number,
+			Hash:     hash,
+			LoadedAt: time.Now(),
+			Report:   report,
+		},
+	})
+	r.stamp = stamp
+
+	return true, nil
+}
+
+// Watch будет проверять файлы сообщений с интервалом interval (см. Reload) до отмены ctx.
+// Ошибки перезагрузки передаются в onError, если он задан.
+func (r *ReloadingLocalizer) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (r *ReloadingLocalizer) current() *reloadState {
+	s, _ := r.state.Load().(*reloadState)
+	if s == nil {
+		return &reloadState{}
+	}
+	return s
+}
+
+// fail сохранит ошибку перезагрузки в текущей версии.
+func (r *ReloadingLocalizer) fail(err error) error {
+	err = Wrap(ErrMessagesReload.WithOptions(SetOperation("ReloadingLocalizer.Reload")), err)
+
+	if cur, _ := r.state.Load().(*reloadState); cur != nil {
+		next := *cur
+		next.version.Err = err
+		r.state.Store(&next)
+	}
+	return err
+}
+
+// scan вернет пути файлов сообщений и отпечаток их размеров и времени изменения.
+func (r *ReloadingLocalizer) scan() ([]string, string, error) {
+	var (
+		files []string
+		stamp []byte
+	)
+	err := fs.WalkDir(r.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := messageFileLang(path.Base(p)); !ok {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, p)
+		stamp = append(stamp, p...)
+		stamp = append(stamp, ' ')
+		stamp = strconv.AppendInt(stamp, info.Size(), 10)
+		stamp = append(stamp, ' ')
+		stamp = strconv.AppendInt(stamp, info.ModTime().UnixNano(), 10)
+		stamp = append(stamp, '\n')
+		return nil
+	})
+	return files, string(stamp), err
+}
+
+// read прочитает файлы и вернет их содержимое и хэш путей и содержимого.
+func (r *ReloadingLocalizer) read(files []string) ([]messageFile, string, error) {
+	read := make([]messageFile, 0, len(files))
+	h := sha256.New()
+	for _, p := range files {
+		data, err := fs.ReadFile(r.fsys, p)
+		if err != nil {
+			return nil, "", err
+		}
+		read = append(read, messageFile{path: p, data: data})
+		_, _ = h.Write([]byte(p))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write(data)
+		_, _ = h.Write([]byte{0})
+	}
+	return read, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// reloadingLocalizer локализатор текущей версии сообщений ReloadingLocalizer.
+type reloadingLocalizer struct {
+	r     *ReloadingLocalizer
+	langs []string
+}
+
+func (l *reloadingLocalizer) Localize(conf *i18n.LocalizeConfig) (string, error) {
+	pool := l.r.Pool()
+	if pool == nil {
+		return "", ErrNoLocalizer
+	}
+	return pool.Localizer(l.langs...).Localize(conf)
+}
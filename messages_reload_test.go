@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestReloadingLocalizer(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "active.ru.toml")
+	write := func(data string) {
+		require.NoError(t, os.WriteFile(file, []byte(data), 0o600))
+		// время изменения может совпасть с предыдущим
+		mtime := time.Now().Add(time.Duration(len(data)) * time.Second)
+		require.NoError(t, os.Chtimes(file, mtime, mtime))
+	}
+	translate := func(l Localizer) string {
+		msg, _ := Translate(NewWith(SetID("ErrHello"), SetMsg("hello")), l, nil)
+		return msg
+	}
+
+	write(`ErrHello = "привет"`)
+
+	r, err := NewReloadingLocalizer(os.DirFS(dir), language.English)
+	require.NoError(t, err)
+	l := r.Localizer("ru")
+	require.Equal(t, "привет", translate(l))
+
+	v1 := r.Version()
+	require.Equal(t, uint64(1), v1.Number)
+	require.NotEmpty(t, v1.Hash)
+	require.Equal(t, 1, v1.Report.Messages[language.Russian])
+
+	// без изменений
+	changed, err := r.Reload()
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// изменено время, но не содержимое
+	write(`ErrHello = "привет"`)
+	require.NoError(t, os.Chtimes(file, time.Now(), time.Now()))
+	changed, err = r.Reload()
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	write(`ErrHello = "здравствуйте"`)
+	changed, err = r.Reload()
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, "здравствуйте", translate(l))
+	require.Equal(t, uint64(2), r.Version().Number)
+
+	// ошибка разбора: остается предыдущая версия
+	write(`ErrHello = `)
+	changed, err = r.Reload()
+	require.Error(t, err)
+	require.False(t, changed)
+	require.True(t, ContainsByID(err, ErrMessagesReload.ID()))
+	require.Equal(t, "здравствуйте", translate(l))
+	require.Equal(t, uint64(2), r.Version().Number)
+	require.Equal(t, err, r.Version().Err)
+
+	// неизменные файлы с ошибкой повторно не разбираются, ошибка сохраняется
+	changed, err = r.Reload()
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.True(t, ContainsByID(r.Version().Err, ErrMessagesReload.ID()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	write(`ErrHello = "добрый день"`)
+	go r.Watch(ctx, 10*time.Millisecond, nil)
+	require.Eventually(t, func() bool {
+		return translate(l) == "добрый день"
+	}, time.Second, 10*time.Millisecond)
+	require.Nil(t, r.Version().Err)
+}
+
+func TestNewReloadingLocalizerError(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "active.ru.toml"), []byte("="), 0o600))
+
+	_, err := NewReloadingLocalizer(os.DirFS(dir), language.English)
+	require.True(t, ContainsByID(err, ErrMessagesReload.ID()))
+	require.True(t, ContainsByID(err, ErrMessageFileParse.ID()))
+}
+
+// changingFS файловая система, содержимое файла active.ru.toml которой меняется при каждом открытии.
+type changingFS struct {
+	files  fstest.MapFS
+	opened int
+}
+
+func (f *changingFS) Open(name string) (fs.File, error) {
+	if name != "active.ru.toml" {
+		return f.files.Open(name)
+	}
+	f.opened++
+	data := []byte(`ErrHello = "v` + strconv.Itoa(f.opened) + `"`)
+	return fstest.MapFS{name: {Data: data}}.Open(name)
+}
+
+func TestReloadingLocalizerLoadsHashedContent(t *testing.T) {
+	fsys := &changingFS{files: fstest.MapFS{"active.ru.toml": {Data: []byte(`ErrHello = "v0"`)}}}
+
+	r, err := NewReloadingLocalizer(fsys, language.English)
+	require.NoError(t, err)
+	require.Equal(t, 1, fsys.opened)
+
+	msg, _ := Translate(NewWith(SetID("ErrHello")), r.Localizer("ru"), nil)
+	require.Equal(t, "v1", msg)
+}
@@ -0,0 +1,63 @@
+package errors
+
+import (
+	"testing"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestDefaultMessages(t *testing.T) {
+	msgs := DefaultMessages()
+	require.Len(t, msgs, 2+13)
+
+	ids := make(map[string]struct{}, len(msgs))
+	for _, m := range msgs {
+		ids[m.ID] = struct{}{}
+	}
+	require.Len(t, ids, len(msgs))
+	require.Contains(t, ids, "ErrorsTypeNotFound")
+	require.Contains(t, ids, "ErrorsTypeUnavailable")
+
+	bundle := i18n.NewBundle(language.English)
+	require.NoError(t, bundle.AddMessages(language.English, msgs...))
+}
+
+func TestTranslateErrType(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.Russian,
+		&i18n.Message{ID: ErrTypeMessageID(NotFound), Other: "НеНайдено"},
+		&i18n.Message{ID: MultierrHeaderID, Other: "произошли ошибки:"},
+		&i18n.Message{
+			ID:    MultierrTruncatedID,
+			One:   "… и еще {{.Count}} ошибка",
+			Few:   "… и еще {{.Count}} ошибки",
+			Many:  "… и еще {{.Count}} ошибок",
+			Other: "… и еще {{.Count}} ошибки",
+		},
+	)
+	ru := i18n.NewLocalizer(bundle, "ru")
+
+	require.Equal(t, "НеНайдено", TranslateErrType(NotFound, ru))
+	require.Equal(t, "Validation", TranslateErrType(Validation, ru))
+	require.Equal(t, "NotFound", TranslateErrType(NotFound, nil))
+
+	err := CombineBounded(1, TruncateKeepFirst, NotFoundErr("a"), ValidationErr("b"), New("c"))
+	data, _ := (&MarshalString{Localizer: ru}).Marshal(err)
+	require.Equal(t, "произошли ошибки:\n"+
+		"\t#1 (НеНайдено) a\n"+
+		"\t… и еще 2 ошибки\n", string(data))
+
+	err = CombineBounded(1, TruncateKeepFirst, NotFoundErr("a"), ValidationErr("b"))
+	require.Equal(t, "the following errors occurred:\n"+
+		"\t#1 (NotFound) a\n"+
+		"\t… and 1 more error\n", err.Error())
+	data, _ = (&MarshalString{Localizer: i18n.NewLocalizer(bundle, "en")}).Marshal(err)
+	require.Equal(t, "the following errors occurred:\n"+
+		"\t#1 (NotFound) a\n"+
+		"\t… and 1 more error\n", string(data))
+
+	e := NotFoundErrWith(SetMsg("a"), SetLocalizer(ru))
+	require.Equal(t, "(НеНайдено) a", e.Error())
+}
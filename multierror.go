@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"io"
 )
 
 // Combine создаст цепочку ошибок из ошибок ...errors.
@@ -28,8 +29,8 @@ func Wrap(left error, right error) error {
 		return left
 	}
 
-	if _, okright := right.(*multiError); !okright { //nolint:errorlint
-		if _, okleft := left.(*multiError); !okleft { //nolint:errorlint
+	if _, okright := nestedErrors(right); !okright {
+		if _, okleft := nestedErrors(left); !okleft {
 			// Both errors are single errors.
 			return &multiError{errors: []error{left, right}}
 		}
@@ -62,12 +63,19 @@ type Multierror interface {
 	Marshal(fn ...Marshaller) ([]byte, error)
 	Len() int
 	Log(l ...Logger)
-	Unwrap() error
 	Last() error
+	Truncated() int
+	Operation() string
 }
 
 type multiError struct {
 	errors []error
+	// truncated число отброшенных ошибок (см. CombineBounded).
+	truncated int
+	// operation имя операции узла дерева ошибок (см. CombineTree).
+	operation string
+	// tree признак узла дерева: такой multiError не разворачивается при объединении.
+	tree bool
 }
 
 // Errors returns the copy list of underlying errors.
@@ -98,7 +106,15 @@ func (merr *multiError) Marshal(fn ...Marshaller) ([]byte, error) {
 func (merr *multiError) Format(f fmt.State, c rune) {
 	var marshal Marshaller
 	switch c {
-	case 'w', 'v', 's':
+	case 's':
+		if f.Flag('+') {
+			// Translate в случае ошибки перевода
+			// возвращает оригинальные сообщения
+			_, _ = io.WriteString(f, DefaultTranslate(merr))
+			return
+		}
+		marshal = &MarshalString{}
+	case 'w', 'v':
 		marshal = &MarshalString{}
 	case 'j':
 		marshal = &MarshalJSON{}
@@ -106,18 +122,24 @@ func (merr *multiError) Format(f fmt.State, c rune) {
 	_ = marshal.MarshalTo(merr, f)
 }
 
+// Operation вернет имя операции узла дерева ошибок (см. CombineTree).
+// Для плоской цепочки вернется "".
+func (merr *multiError) Operation() string {
+	return merr.operation
+}
+
 func (merr *multiError) Len() int {
 	return len(merr.errors)
 }
 
-// Unwrap вернет самую новую ошибку в стеке
-func (merr *multiError) Unwrap() error {
-	return merr.Last()
+// Truncated вернет число ошибок, не вошедших в цепочку из-за ограничения ее размера.
+func (merr *multiError) Truncated() int {
+	return merr.truncated
 }
 
 // Last вернет самую новую (*Error) ошибку в стеке
 func (merr *multiError) Last() error {
-	if merr.errors == nil {
+	if len(merr.errors) == 0 {
 		return nil
 	}
 	return merr.errors[0]
@@ -175,8 +197,8 @@ func inspect(errors []error) inspectResult {
 			res.firstErrorIdx = i
 		}
 
-		if merr, ok := err.(*multiError); ok { //nolint:errorlint
-			res.capacity += len(merr.errors)
+		if nested, ok := nestedErrors(err); ok {
+			res.capacity += len(nested)
 			res.containsMultiError = true
 		} else {
 			res.capacity++
@@ -214,17 +236,32 @@ func fromSlice(errors []error) error {
 	}
 
 	nonNilErrs := make([]error, 0, res.capacity)
+	truncated := 0
 	for _, err := range errors[res.firstErrorIdx:] {
 		if err == nil {
 			continue
 		}
 
-		if nested, ok := err.(*multiError); ok { //nolint:errorlint
-			nonNilErrs = append(nonNilErrs, nested.errors...)
+		if nested, ok := nestedErrors(err); ok {
+			nonNilErrs = append(nonNilErrs, nested...)
+			truncated += truncatedCount(err)
 		} else {
 			nonNilErrs = append(nonNilErrs, err)
 		}
 	}
 
-	return &multiError{errors: nonNilErrs}
+	return &multiError{errors: nonNilErrs, truncated: truncated}
+}
+
+// nestedErrors вернет элементы цепочки, если err является multiError
+// или ошибкой, полученной с помощью errors.Join.
+// Узлы дерева ошибок (см. CombineTree) не разворачиваются.
+func nestedErrors(err error) ([]error, bool) {
+	if merr, ok := err.(*multiError); ok { //nolint:errorlint
+		if merr.tree {
+			return nil, false
+		}
+		return merr.errors, true
+	}
+	return joinedErrors(err)
 }
@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"math/rand"
+)
+
+// TruncateMode способ ограничения размера цепочки ошибок.
+type TruncateMode int
+
+const (
+	// TruncateKeepFirst сохранить первые N ошибок.
+	TruncateKeepFirst TruncateMode = iota
+	// TruncateKeepLast сохранить последние N ошибок.
+	TruncateKeepLast
+	// TruncateSample сохранить случайную выборку из N ошибок.
+	TruncateSample
+)
+
+// CombineBounded как и Combine создаст цепочку ошибок из ошибок ...errs,
+// но сохранит не более limit ошибок, выбранных способом mode.
+// Число отброшенных ошибок доступно через Multierror.Truncated()
+// и выводится маршалерами.
+// При limit <= 0 размер цепочки не ограничивается.
+func CombineBounded(limit int, mode TruncateMode, errs ...error) error {
+	b := bounded{limit: limit, mode: mode}
+	for _, err := range errs {
+		b.add(err)
+	}
+	return b.err()
+}
+
+// bounded накопитель ошибок ограниченного размера.
+type bounded struct {
+	limit int
+	mode  TruncateMode
+
+	errs []error
+	// total число всех добавленных ошибок
+	total int
+	// dropped число ошибок, отброшенных до добавления в накопитель
+	dropped int
+}
+
+func (b *bounded) add(err error) {
+	if err == nil {
+		return
+	}
+
+	if nested, ok := nestedErrors(err); ok {
+		b.dropped += truncatedCount(err)
+		for _, e := range nested {
+			b.add(e)
+		}
+		return
+	}
+
+	b.total++
+	if b.limit <= 0 || len(b.errs) < b.limit {
+		b.errs = append(b.errs, err)
+		return
+	}
+
+	switch b.mode {
+	case TruncateKeepFirst:
+	case TruncateKeepLast:
+		b.errs = append(b.errs[1:], err)
+	case TruncateSample:
+		if i := rand.Intn(b.total); i < b.limit { //nolint:gosec
+			b.errs[i] = err
+		}
+	}
+}
+
+func (b *bounded) truncated() int {
+	return b.total - len(b.errs) + b.dropped
+}
+
+func (b *bounded) err() error {
+	truncated := b.truncated()
+	if truncated == 0 {
+		return fromSlice(b.errs)
+	}
+	return &multiError{
+		errors:    append([]error(nil), b.errs...),
+		truncated: truncated,
+	}
+}
+
+// truncatedCount вернет число отброшенных ошибок цепочки i.
+func truncatedCount(i interface{}) int {
+	if t, ok := i.(interface{ Truncated() int }); ok {
+		return t.Truncated()
+	}
+	return 0
+}
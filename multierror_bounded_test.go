@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testErrs(n int) []error {
+	errs := make([]error, 0, n)
+	for i := 0; i < n; i++ {
+		errs = append(errs, New(strconv.Itoa(i)))
+	}
+	return errs
+}
+
+func TestCombineBounded(t *testing.T) {
+	errs := testErrs(10)
+
+	tests := []struct {
+		name string
+		mode TruncateMode
+		want []error
+	}{
+		{
+			name: "first",
+			mode: TruncateKeepFirst,
+			want: errs[:3],
+		},
+		{
+			name: "last",
+			mode: TruncateKeepLast,
+			want: errs[7:],
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			merr, ok := CombineBounded(3, tt.mode, errs...).(Multierror) //nolint:errorlint
+			require.True(t, ok)
+			require.Equal(t, tt.want, merr.Errors())
+			require.Equal(t, 7, merr.Truncated())
+		})
+	}
+
+	merr, ok := CombineBounded(3, TruncateSample, errs...).(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, 3, merr.Len())
+	require.Equal(t, 7, merr.Truncated())
+	require.Subset(t, errs, merr.Errors())
+
+	require.Nil(t, CombineBounded(3, TruncateKeepFirst, nil, nil))
+	require.Equal(t, errs[0], CombineBounded(3, TruncateKeepFirst, errs[0]))
+}
+
+func TestCombineBoundedNested(t *testing.T) {
+	errs := testErrs(10)
+
+	err := Combine(CombineBounded(2, TruncateKeepFirst, errs[:5]...), errs[5])
+	merr, ok := err.(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, []error{errs[0], errs[1], errs[5]}, merr.Errors())
+	require.Equal(t, 3, merr.Truncated())
+	filtered, ok := Filter(err, func(error) bool { return true }).(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, 3, filtered.Truncated())
+
+	err = CombineBounded(1, TruncateKeepFirst, err, errs[6])
+	merr, ok = err.(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, []error{errs[0]}, merr.Errors())
+	require.Equal(t, 6, merr.Truncated())
+}
+
+func TestMarshalTruncated(t *testing.T) {
+	errs := testErrs(12)
+	errs = append(errs, make([]error, 99900-len(errs))...)
+	for i := 12; i < len(errs); i++ {
+		errs[i] = errs[i%12]
+	}
+
+	err := CombineBounded(2, TruncateKeepFirst, errs...)
+
+	require.Equal(t,
+		"the following errors occurred:\n\t#1 0\n\t#2 1\n\t… and 99 898 more errors\n",
+		err.Error(),
+	)
+
+	data, _ := (&MarshalJSON{}).Marshal(err)
+	require.Equal(t,
+		`{"count":2,"truncated":99898,"messages":[{"id":"","operation":"","error_type":"Unknown","context":null,"msg":"0"},{"id":"","operation":"","error_type":"Unknown","context":null,"msg":"1"}]}`,
+		string(data),
+	)
+}
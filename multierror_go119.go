@@ -0,0 +1,51 @@
+//go:build !go1.20
+// +build !go1.20
+
+package errors
+
+import "reflect"
+
+type unwrapper interface {
+	Unwrap() error
+}
+
+// Unwrap вернет самую новую ошибку в стеке
+func (merr *multiError) Unwrap() error {
+	return merr.Last()
+}
+
+func joinedErrors(error) ([]error, bool) {
+	return nil, false
+}
+
+// is обойдет дерево ошибок (см. Walk): до Go 1.20 errors.Is для multiError
+// проверяет только Last().
+func is(err, target error) bool {
+	var found bool
+	Walk(err, func(e error, _ int) bool {
+		found = isTarget(e, target)
+		return found
+	})
+	return found
+}
+
+// as обойдет дерево ошибок (см. Walk), как и is.
+func as(err error, target interface{}) bool {
+	val := reflect.ValueOf(target)
+	if target == nil || val.Kind() != reflect.Ptr || val.IsNil() {
+		panic("errors: target must be a non-nil pointer")
+	}
+	targetType := val.Type().Elem()
+
+	var found bool
+	Walk(err, func(e error, _ int) bool {
+		if reflect.TypeOf(e).AssignableTo(targetType) {
+			val.Elem().Set(reflect.ValueOf(e))
+			found = true
+		} else if x, ok := e.(interface{ As(interface{}) bool }); ok && x.As(target) { //nolint:errorlint
+			found = true
+		}
+		return found
+	})
+	return found
+}
@@ -0,0 +1,37 @@
+//go:build go1.20
+// +build go1.20
+
+package errors
+
+import (
+	origerrors "errors"
+	"reflect"
+)
+
+type unwrapper interface {
+	Unwrap() []error
+}
+
+// Unwrap вернет все ошибки цепочки.
+// Позволяет стандартным errors.Is и errors.As обойти каждую ошибку цепочки.
+func (merr *multiError) Unwrap() []error {
+	return merr.Errors()
+}
+
+// is начиная с Go 1.20 errors.Is обходит каждую ошибку цепочки с помощью Unwrap() []error.
+func is(err, target error) bool {
+	return origerrors.Is(err, target)
+}
+
+func as(err error, target interface{}) bool {
+	return origerrors.As(err, target)
+}
+
+var joinErrorType = reflect.TypeOf(origerrors.Join(origerrors.New(""))) //nolint:gochecknoglobals
+
+func joinedErrors(err error) ([]error, bool) {
+	if err == nil || reflect.TypeOf(err) != joinErrorType {
+		return nil, false
+	}
+	return err.(unwrapper).Unwrap(), true //nolint:errorlint,forcetypeassert
+}
@@ -0,0 +1,53 @@
+//go:build go1.20
+// +build go1.20
+
+package errors
+
+import (
+	origerrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultierrorUnwrapAll(t *testing.T) {
+	e1 := New("one")
+	e2 := NewWith(SetMsg("two"), SetID("two"))
+	e3 := origerrors.New("three")
+
+	err := Combine(e1, e2, e3)
+
+	require.True(t, origerrors.Is(err, e1))
+	require.True(t, origerrors.Is(err, e2))
+	require.True(t, origerrors.Is(err, e3))
+	require.True(t, Is(err, e3))
+
+	var target *Error
+	require.True(t, origerrors.As(err, &target))
+	require.Equal(t, e1, target)
+}
+
+func TestCombineJoined(t *testing.T) {
+	e1 := New("one")
+	e2 := NewWith(SetMsg("two"), SetID("two"))
+	e3 := origerrors.New("three")
+
+	err := Combine(origerrors.Join(e1, e2), e3)
+
+	merr, ok := err.(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, []error{e1, e2, e3}, merr.Errors())
+
+	err = Wrap(e3, origerrors.Join(e1, nil, e2))
+	merr, ok = err.(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, []error{e3, e1, e2}, merr.Errors())
+
+	joined := origerrors.Join(e1, e2)
+	require.Equal(t, e2, FindByID(joined, "two"))
+	require.True(t, ContainsByID(joined, "two"))
+
+	merr, ok = CastMultierr(joined)
+	require.True(t, ok)
+	require.Equal(t, []error{e1, e2}, merr.Errors())
+}
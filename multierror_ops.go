@@ -0,0 +1,209 @@
+package errors
+
+import (
+	"sort"
+	"strings"
+)
+
+// Операции над цепочками ошибок.
+// Функции принимают Multierror или одиночную ошибку (цепочку из одного элемента)
+// и возвращают новую цепочку, исходная цепочка не изменяется.
+// Результат строится как и в Combine: плоский, без nil;
+// если ошибок не осталось, вернется nil, если осталась одна -- сама ошибка.
+// Узлы дерева ошибок (см. CombineTree) и число отброшенных ошибок (см. CombineBounded)
+// сохраняются.
+
+// Filter вернет цепочку из ошибок err, для которых fn вернула true.
+func Filter(err error, fn func(error) bool) error {
+	src, errs := chainOf(err)
+	out := make([]error, 0, len(errs))
+	for _, e := range errs {
+		if fn(e) {
+			out = append(out, e)
+		}
+	}
+	return derive(src, out)
+}
+
+// Map вернет цепочку из результатов fn для каждой ошибки err.
+// Если fn вернет nil, ошибка будет исключена из цепочки,
+// если вернет Multierror -- ее элементы будут встроены в цепочку.
+func Map(err error, fn func(error) error) error {
+	src, errs := chainOf(err)
+	out := make([]error, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, fn(e))
+	}
+
+	res := flatten(out)
+	if src != nil {
+		res.truncated += src.truncated
+	}
+	return derive(src, res.errors, res.truncated)
+}
+
+// Dedup вернет цепочку err без повторов. Повтором считается ошибка с совпадающим Fingerprint.
+// Из повторов сохраняется первая ошибка.
+func Dedup(err error) error {
+	return DedupBy(err, Fingerprint)
+}
+
+// DedupBy вернет цепочку err без повторов, ошибки сравниваются по ключу key.
+// Из повторов сохраняется первая ошибка.
+func DedupBy(err error, key func(error) string) error {
+	src, errs := chainOf(err)
+	seen := make(map[string]struct{}, len(errs))
+	out := make([]error, 0, len(errs))
+	for _, e := range errs {
+		k := key(e)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, e)
+	}
+	return derive(src, out)
+}
+
+// SortBy вернет отсортированную с помощью less цепочку err.
+// Сортировка стабильная: порядок равных ошибок сохраняется.
+func SortBy(err error, less func(a, b error) bool) error {
+	src, errs := chainOf(err)
+	out := append([]error(nil), errs...)
+	sort.SliceStable(out, func(i, j int) bool {
+		return less(out[i], out[j])
+	})
+	return derive(src, out)
+}
+
+// GroupBy разобьет цепочку err на группы по ключу key.
+// Порядок ошибок внутри группы сохраняется.
+// Группа из одной ошибки -- сама ошибка (см. Combine).
+func GroupBy(err error, key func(error) string) map[string]error {
+	src, errs := chainOf(err)
+	groups := make(map[string][]error)
+	for _, e := range errs {
+		k := key(e)
+		groups[k] = append(groups[k], e)
+	}
+
+	out := make(map[string]error, len(groups))
+	for k, g := range groups {
+		// отброшенные ошибки не относятся ни к одной из групп
+		out[k] = derive(src, g, 0)
+	}
+	return out
+}
+
+// chainOf вернет multiError err (или nil) и элементы цепочки.
+func chainOf(err error) (*multiError, []error) {
+	if err == nil {
+		return nil, nil
+	}
+	if merr, ok := err.(*multiError); ok { //nolint:errorlint
+		return merr, merr.errors
+	}
+	if nested, ok := joinedErrors(err); ok {
+		return nil, nested
+	}
+	return nil, []error{err}
+}
+
+// derive вернет цепочку из ошибок errors с параметрами src.
+// Число отброшенных ошибок можно переопределить с помощью truncated.
+// Если ошибок не осталось, вернется nil, даже если часть ошибок была отброшена.
+func derive(src *multiError, errors []error, truncated ...int) error {
+	out := &multiError{errors: errors}
+	if src != nil {
+		out.truncated = src.truncated
+		out.operation = src.operation
+		out.tree = src.tree
+	}
+	if len(truncated) > 0 {
+		out.truncated = truncated[0]
+	}
+
+	switch {
+	case len(out.errors) == 0:
+		return nil
+	case len(out.errors) == 1 && out.truncated == 0 && !out.tree:
+		return out.errors[0]
+	}
+	return out
+}
+
+// flatten удалит nil и встроит элементы вложенных цепочек.
+func flatten(errors []error) *multiError {
+	res := inspect(errors)
+	out := &multiError{errors: make([]error, 0, res.capacity)}
+	for _, err := range errors {
+		if err == nil {
+			continue
+		}
+
+		if nested, ok := nestedErrors(err); ok {
+			out.errors = append(out.errors, nested...)
+			out.truncated += truncatedCount(err)
+		} else {
+			out.errors = append(out.errors, err)
+		}
+	}
+	return out
+}
+
+// ключи и функции сравнения
+
+// Fingerprint вернет отпечаток ошибки.
+// Для *Error учитываются ID, тип, операция, контекст и сообщение,
+// для прочих ошибок -- Error().
+func Fingerprint(err error) string {
+	e, ok := err.(*Error) //nolint:errorlint
+	if !ok {
+		if err == nil {
+			return ""
+		}
+		return err.Error()
+	}
+
+	var b strings.Builder
+	_, _ = b.WriteString(e.ID())
+	_ = b.WriteByte(0)
+	et, _ := GetErrType(e)
+	_, _ = b.WriteString(et.String())
+	_ = b.WriteByte(0)
+	_, _ = b.WriteString(e.Operation())
+	_ = b.WriteByte(0)
+	contextInfoFormat(&b, e.ContextInfo(), false)
+	_ = b.WriteByte(0)
+	_, _ = b.WriteString(e.Msg())
+	return b.String()
+}
+
+// KeyByID ключ ошибки по ID.
+// Для ошибок без ID используется Fingerprint, чтобы они не объединялись в одну группу.
+func KeyByID(err error) string {
+	if id := GetID(err); id != "" {
+		return id
+	}
+	return Fingerprint(err)
+}
+
+// KeyByErrorType ключ ошибки по названию ее типа.
+// Для НЕ *Error будет использован тип по-умолчанию.
+func KeyByErrorType(err error) string {
+	et, _ := GetErrType(err)
+	return et.String()
+}
+
+// LessByErrorType сравнение ошибок по важности их типа: ошибки сервера идут перед ошибками клиента
+// (см. AggregateMostSevere).
+func LessByErrorType(a, b error) bool {
+	aet, _ := GetErrType(a)
+	bet, _ := GetErrType(b)
+	return severityRank(aet) < severityRank(bet)
+}
+
+// LessByID сравнение ошибок по ID.
+func LessByID(a, b error) bool {
+	return GetID(a) < GetID(b)
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func errorsOf(err error) []error {
+	if merr, ok := err.(Multierror); ok { //nolint:errorlint
+		return merr.Errors()
+	}
+	return []error{err}
+}
+
+func TestFilter(t *testing.T) {
+	e1 := NotFoundErr("one")
+	e2 := ValidationErr("two")
+	e3 := NotFoundErr("three")
+
+	merr := Combine(e1, e2, e3)
+
+	isNotFound := func(e error) bool {
+		et, _ := GetErrType(e)
+		return et == NotFound
+	}
+	require.Equal(t, []error{e1, e3}, errorsOf(Filter(merr, isNotFound)))
+	require.Len(t, errorsOf(merr), 3)
+
+	// одна ошибка -- сама ошибка, пустая цепочка -- nil
+	require.Equal(t, e2, Filter(merr, func(e error) bool { return e == e2 }))
+	require.Nil(t, Filter(merr, func(error) bool { return false }))
+	require.Nil(t, Filter(nil, isNotFound))
+	require.Equal(t, e1, Filter(e1, isNotFound))
+	require.Nil(t, Filter(e2, isNotFound))
+
+	// число отброшенных ошибок сохраняется
+	bounded := CombineBounded(1, TruncateKeepFirst, e1, e2, e3)
+	got, ok := Filter(bounded, isNotFound).(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, []error{e1}, got.Errors())
+	require.Equal(t, 2, got.Truncated())
+
+	// без ошибок отброшенные не печатаются
+	require.Nil(t, Filter(bounded, func(error) bool { return false }))
+	require.Nil(t, Map(bounded, func(error) error { return nil }))
+}
+
+func TestMap(t *testing.T) {
+	e1 := New("one")
+	e2 := New("two")
+	e3 := New("three")
+
+	got := Map(Combine(e1, e2), func(e error) error {
+		switch e {
+		case e1:
+			return nil
+		case e2:
+			return Combine(e2, e3)
+		}
+		return e
+	})
+	require.Equal(t, []error{e2, e3}, errorsOf(got))
+
+	require.Nil(t, Map(Combine(e1, e2), func(error) error { return nil }))
+}
+
+func TestDedup(t *testing.T) {
+	e1 := NewWith(SetID("id1"), SetMsg("one"))
+	e11 := NewWith(SetID("id1"), SetMsg("one more"))
+	e2 := New("two")
+
+	merr := Combine(e1, e2, e1, New("two"), e11)
+
+	require.Equal(t, []error{e1, e2, e11}, errorsOf(Dedup(merr)))
+	require.Equal(t, []error{e1, e2}, errorsOf(DedupBy(merr, KeyByID)))
+	require.Equal(t, e1, Dedup(Combine(e1, e1)))
+}
+
+func TestSortBy(t *testing.T) {
+	e1 := NotFoundErr("one")
+	e2 := IternalErr("two")
+	e3 := NotFoundErr("three")
+	e4 := New("four")
+	e5 := UnavailableErr("five")
+
+	merr := Combine(e1, e2, e3, e4, e5)
+
+	// по важности: ошибки сервера перед ошибками клиента
+	require.Equal(t, []error{e2, e5, e4, e1, e3}, errorsOf(SortBy(merr, LessByErrorType)))
+	require.Equal(t, []error{e1, e2, e3, e4, e5}, errorsOf(merr))
+}
+
+func TestGroupBy(t *testing.T) {
+	e1 := NotFoundErr("one")
+	e2 := ValidationErr("two")
+	e3 := NotFoundErr("three")
+
+	groups := GroupBy(Combine(e1, e2, e3), KeyByErrorType)
+	require.Len(t, groups, 2)
+	require.Equal(t, []error{e1, e3}, errorsOf(groups[NotFound.String()]))
+	require.Equal(t, e2, groups[Validation.String()])
+
+	require.Empty(t, GroupBy(nil, KeyByErrorType))
+}
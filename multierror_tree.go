@@ -0,0 +1,37 @@
+package errors
+
+// CombineTree создаст узел дерева ошибок с именем операции operation из ошибок ...errs.
+// В отличие от Combine, узел не разворачивается при объединении с другими ошибками,
+// поэтому сохраняется информация о том, к какой операции относится каждая ошибка.
+// Плоские цепочки в errs разворачиваются, вложенные узлы сохраняются.
+// Допускается использование `nil` в аргументах. Если все ошибки nil, вернется nil.
+//
+// Имя операции узла доступно через Multierror.Operation() и выводится маршалерами:
+// MarshalString выводит вложенные узлы с отступом, MarshalJSON -- вложенными объектами.
+// Глубину узлов можно получить с помощью Walk.
+func CombineTree(operation string, errs ...error) error {
+	node := flatten(errs)
+	if node.Len() == 0 && node.Truncated() == 0 {
+		return nil
+	}
+	node.operation = operation
+	node.tree = true
+	return node
+}
+
+// treeNode вернет узел дерева ошибок, если err им является.
+func treeNode(err error) (*multiError, bool) {
+	merr, ok := err.(*multiError) //nolint:errorlint
+	if !ok || !merr.tree {
+		return nil, false
+	}
+	return merr, true
+}
+
+// operationOf вернет имя операции i, если i его предоставляет.
+func operationOf(i interface{}) string {
+	if o, ok := i.(interface{ Operation() string }); ok {
+		return o.Operation()
+	}
+	return ""
+}
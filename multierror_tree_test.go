@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineTree(t *testing.T) {
+	e1 := NotFoundErr("user not found")
+	e2 := New("two")
+	e3 := ValidationErr("bad email")
+
+	load := CombineTree("load", e1, nil, e2)
+	save := CombineTree("save", e3)
+
+	err := Combine(load, save, New("flat"))
+
+	merr, ok := err.(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, 3, merr.Len())
+	require.Equal(t, "", merr.Operation())
+	require.Equal(t, "load", GetOperation(merr.Errors()[0]))
+	require.Equal(t, "save", GetOperation(merr.Errors()[1]))
+
+	require.Nil(t, CombineTree("empty", nil, nil))
+	require.Equal(t, e3, FindByErr(err, e3))
+
+	depths := map[error]int{}
+	Walk(err, func(e error, depth int) bool {
+		depths[e] = depth
+		return false
+	})
+	require.Equal(t, 1, depths[load])
+	require.Equal(t, 2, depths[e1])
+	require.Equal(t, 2, depths[e3])
+
+	require.Equal(t, `the following errors occurred:
+	#1 [load] the following errors occurred:
+		#1 (NotFound) user not found
+		#2 two
+	#2 [save] the following errors occurred:
+		#1 (Validation) bad email
+	#3 flat
+`, err.Error())
+
+	data, _ := (&MarshalJSON{}).Marshal(save)
+	require.Equal(t,
+		`{"operation":"save","count":1,"messages":[{"id":"","operation":"","error_type":"Validation","context":null,"msg":"bad email"}]}`,
+		string(data),
+	)
+
+	data, _ = (&MarshalJSON{}).Marshal(Combine(save, e2))
+	require.Equal(t,
+		`{"count":2,"messages":[{"operation":"save","count":1,"messages":[{"id":"","operation":"","error_type":"Validation","context":null,"msg":"bad email"}]},{"id":"","operation":"","error_type":"Unknown","context":null,"msg":"two"}]}`,
+		string(data),
+	)
+}
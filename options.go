@@ -2,7 +2,10 @@ package errors
 
 import (
 	"reflect"
+	"time"
 	"unsafe"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
 // Options опции-параметры ошибки.
@@ -85,6 +88,90 @@ func AppendContextInfo(key string, value interface{}) Options {
 	}
 }
 
+// Retry
+
+// SetRetryable, bool. Установит признак того, что повтор операции может быть успешным.
+// Переопределяет значение по-умолчанию для типа ошибки.
+func SetRetryable(retryable bool) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		e.retryable = retryNo
+		if retryable {
+			e.retryable = retryYes
+		}
+	}
+}
+
+// SetRetryAfter, time.Duration. Установит рекомендуемую задержку перед повтором операции.
+func SetRetryAfter(d time.Duration) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		e.retryAfter = d
+		e.retryAt = time.Time{}
+	}
+}
+
+// SetRetryAt, time.Time. Установит время, не ранее которого следует повторить операцию.
+func SetRetryAt(t time.Time) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		e.retryAt = t
+		e.retryAfter = 0
+	}
+}
+
+// Translate
+
+// SetLocalizer, Localizer. Установит локализатор ошибки.
+// Используется вместо DefaultLocalizer, если локализатор не указан явно.
+// Для ошибки с локализатором маршалеры выводят переведенное сообщение.
+func SetLocalizer(l Localizer) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		e.localizer = l
+	}
+}
+
+// SetTemplateData, map[string]interface{}. Установит данные для шаблона перевода.
+// Если данные не заданы, они будут сформированы из контекста CtxKV.
+func SetTemplateData(data map[string]interface{}) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		e.templateData = data
+	}
+}
+
+// SetPluralCount, nil или число. Установит признак множественности для перевода.
+func SetPluralCount(count interface{}) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		e.pluralCount = count
+	}
+}
+
+// SetDefaultMessage, *i18n.Message. Установит сообщение,
+// которое будет использовано, если для языка нет перевода.
+func SetDefaultMessage(msg *i18n.Message) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		e.defaultMessage = msg
+	}
+}
+
 //
 // from https://github.com/valyala/fastjson/blob/master/util.go
 //
@@ -0,0 +1,182 @@
+package po
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ovsinc/errors"
+)
+
+// entry запись .po-файла.
+type entry struct {
+	ctx      string
+	id       string
+	idPlural string
+	strs     []string
+	fuzzy    bool
+	obsolete bool
+}
+
+// key ключ записи каталога, как в gettext: msgctxt "\x04" msgid.
+func key(ctx, id string) string {
+	if ctx == "" {
+		return id
+	}
+	return ctx + "\x04" + id
+}
+
+// parser построчный разбор .po-файла.
+type parser struct {
+	entries []*entry
+	cur     *entry
+	// field поле, в которое добавляются строки продолжения
+	field *string
+	line  int
+	// started в текущей записи уже был msgid/msgctxt
+	started bool
+}
+
+func parse(r io.Reader) ([]*entry, error) {
+	p := &parser{}
+	p.reset()
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		p.line++
+		if err := p.parseLine(strings.TrimSpace(sc.Text())); err != nil {
+			return nil, err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	p.flush()
+
+	return p.entries, nil
+}
+
+func (p *parser) reset() {
+	p.cur = &entry{}
+	p.field = nil
+	p.started = false
+}
+
+func (p *parser) flush() {
+	if p.started {
+		p.entries = append(p.entries, p.cur)
+	}
+	p.reset()
+}
+
+func (p *parser) errorf(msg string) error {
+	return errors.InputBodyErrWith(
+		errors.SetMsg("po: "+msg),
+		errors.AppendContextInfo("line", p.line),
+	)
+}
+
+func (p *parser) parseLine(line string) error { //nolint:cyclop
+	switch {
+	case line == "":
+		p.flush()
+		return nil
+
+	case strings.HasPrefix(line, "#~"):
+		// устаревшая запись
+		if p.started && len(p.cur.strs) > 0 && !p.cur.obsolete {
+			p.flush()
+		}
+		p.cur.obsolete = true
+		line = strings.TrimSpace(strings.TrimPrefix(line, "#~"))
+		if line == "" {
+			return nil
+		}
+		return p.parseLine(line)
+
+	case strings.HasPrefix(line, "#,"):
+		if p.started && len(p.cur.strs) > 0 {
+			p.flush()
+		}
+		for _, flag := range strings.Split(line[2:], ",") {
+			if strings.TrimSpace(flag) == "fuzzy" {
+				p.cur.fuzzy = true
+			}
+		}
+		return nil
+
+	case strings.HasPrefix(line, "#"):
+		return nil
+
+	case strings.HasPrefix(line, `"`):
+		if p.field == nil {
+			return p.errorf("unexpected string")
+		}
+		s, err := unquote(line)
+		if err != nil {
+			return p.errorf(err.Error())
+		}
+		*p.field += s
+		return nil
+	}
+
+	keyword, value := line, ""
+	if i := strings.IndexAny(line, " \t"); i > 0 {
+		keyword, value = line[:i], strings.TrimSpace(line[i:])
+	}
+	s, err := unquote(value)
+	if err != nil {
+		return p.errorf(err.Error())
+	}
+
+	switch {
+	case keyword == "msgctxt":
+		if p.started && len(p.cur.strs) > 0 {
+			p.flush()
+		}
+		p.started = true
+		p.cur.ctx = s
+		p.field = &p.cur.ctx
+
+	case keyword == "msgid":
+		if p.started && len(p.cur.strs) > 0 {
+			p.flush()
+		}
+		p.started = true
+		p.cur.id = s
+		p.field = &p.cur.id
+
+	case keyword == "msgid_plural":
+		p.cur.idPlural = s
+		p.field = &p.cur.idPlural
+
+	case keyword == "msgstr":
+		p.cur.strs = append(p.cur.strs[:0], s)
+		p.field = &p.cur.strs[0]
+
+	case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+		idx, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+		if err != nil || idx < 0 || idx > 16 {
+			return p.errorf("invalid plural index")
+		}
+		for len(p.cur.strs) <= idx {
+			p.cur.strs = append(p.cur.strs, "")
+		}
+		p.cur.strs[idx] = s
+		p.field = &p.cur.strs[idx]
+
+	default:
+		return p.errorf("unknown keyword " + strconv.Quote(keyword))
+	}
+
+	return nil
+}
+
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", errors.New("invalid string " + strconv.Quote(s))
+	}
+	return strconv.Unquote(s)
+}
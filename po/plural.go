@@ -0,0 +1,229 @@
+package po
+
+import (
+	"strconv"
+
+	"github.com/ovsinc/errors"
+)
+
+// pluralFunc вернет индекс формы множественного числа для n.
+type pluralFunc func(n int64) int64
+
+// germanic правило по-умолчанию: nplurals=2; plural=(n != 1).
+func germanic(n int64) int64 {
+	if n != 1 {
+		return 1
+	}
+	return 0
+}
+
+// parsePlural разберет выражение plural из заголовка Plural-Forms (подмножество C):
+// n, целые числа, скобки, ! * / % + - < <= > >= == != && || ?:.
+func parsePlural(expr string) (pluralFunc, error) {
+	p := &pluralParser{src: expr}
+	p.next()
+	fn, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "" {
+		return nil, p.errorf("unexpected token")
+	}
+	return fn, nil
+}
+
+type pluralParser struct {
+	src string
+	pos int
+	tok string
+}
+
+func (p *pluralParser) errorf(msg string) error {
+	return errors.InputBodyErrWith(
+		errors.SetMsg("plural expression: "+msg),
+		errors.AppendContextInfo("expr", p.src),
+		errors.AppendContextInfo("pos", p.pos),
+	)
+}
+
+// next прочитает следующую лексему в p.tok ("" -- конец выражения).
+func (p *pluralParser) next() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+	if p.pos >= len(p.src) {
+		p.tok = ""
+		return
+	}
+
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case c >= '0' && c <= '9':
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+	case p.pos+1 < len(p.src) && isTwoCharOp(p.src[p.pos:p.pos+2]):
+		p.pos += 2
+	default:
+		p.pos++
+	}
+	p.tok = p.src[start:p.pos]
+}
+
+func isTwoCharOp(s string) bool {
+	switch s {
+	case "<=", ">=", "==", "!=", "&&", "||":
+		return true
+	}
+	return false
+}
+
+func (p *pluralParser) ternary() (pluralFunc, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "?" {
+		return cond, nil
+	}
+
+	p.next()
+	a, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != ":" {
+		return nil, p.errorf("expected ':'")
+	}
+	p.next()
+	b, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(n int64) int64 {
+		if cond(n) != 0 {
+			return a(n)
+		}
+		return b(n)
+	}, nil
+}
+
+// приоритеты бинарных операторов
+var binaryPrecedence = map[string]int{ //nolint:gochecknoglobals
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+func (p *pluralParser) binary(minPrec int) (pluralFunc, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.tok
+		prec, ok := binaryPrecedence[op]
+		if !ok || prec <= minPrec {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.binary(prec)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryOp(op, left, right)
+	}
+}
+
+func (p *pluralParser) unary() (pluralFunc, error) {
+	switch tok := p.tok; {
+	case tok == "!":
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int64) int64 { return bool2int(x(n) == 0) }, nil
+
+	case tok == "(":
+		p.next()
+		x, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok != ")" {
+			return nil, p.errorf("expected ')'")
+		}
+		p.next()
+		return x, nil
+
+	case tok == "n":
+		p.next()
+		return func(n int64) int64 { return n }, nil
+
+	case tok != "" && tok[0] >= '0' && tok[0] <= '9':
+		v, err := strconv.ParseInt(tok, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number")
+		}
+		p.next()
+		return func(int64) int64 { return v }, nil
+	}
+
+	return nil, p.errorf("unexpected token")
+}
+
+func binaryOp(op string, a, b pluralFunc) pluralFunc { //nolint:cyclop
+	switch op {
+	case "||":
+		return func(n int64) int64 { return bool2int(a(n) != 0 || b(n) != 0) }
+	case "&&":
+		return func(n int64) int64 { return bool2int(a(n) != 0 && b(n) != 0) }
+	case "==":
+		return func(n int64) int64 { return bool2int(a(n) == b(n)) }
+	case "!=":
+		return func(n int64) int64 { return bool2int(a(n) != b(n)) }
+	case "<":
+		return func(n int64) int64 { return bool2int(a(n) < b(n)) }
+	case "<=":
+		return func(n int64) int64 { return bool2int(a(n) <= b(n)) }
+	case ">":
+		return func(n int64) int64 { return bool2int(a(n) > b(n)) }
+	case ">=":
+		return func(n int64) int64 { return bool2int(a(n) >= b(n)) }
+	case "+":
+		return func(n int64) int64 { return a(n) + b(n) }
+	case "-":
+		return func(n int64) int64 { return a(n) - b(n) }
+	case "*":
+		return func(n int64) int64 { return a(n) * b(n) }
+	case "/":
+		return func(n int64) int64 {
+			if d := b(n); d != 0 {
+				return a(n) / d
+			}
+			return 0
+		}
+	default: // "%"
+		return func(n int64) int64 {
+			if d := b(n); d != 0 {
+				return a(n) % d
+			}
+			return 0
+		}
+	}
+}
+
+func bool2int(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,313 @@
+// Package po локализатор на основе файлов gettext (.po) для errors.Translate.
+//
+// Поддерживаются формы множественного числа (msgid_plural, msgstr[N], заголовок Plural-Forms)
+// и контекст (msgctxt). Сообщение ищется по errors.Error.ID():
+// сначала среди записей с msgctxt, равным ID, затем среди записей с msgid, равным ID.
+// Перевод является шаблоном text/template, в который подставляются TemplateData.
+// Неточные (fuzzy), устаревшие (#~) и пустые переводы не используются.
+package po
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"text/template"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/ovsinc/errors"
+)
+
+// ErrMessageNotFound перевод сообщения не найден (см. Catalog.Localize).
+var ErrMessageNotFound = errors.NewWith(
+	errors.SetID("ErrPOMessageNotFound"),
+	errors.SetMsg("po: message not found"),
+	errors.SetErrorType(errors.NotFound),
+)
+
+var _ errors.Localizer = (*Catalog)(nil)
+
+// Catalog каталог переводов одного .po-файла.
+type Catalog struct {
+	language string
+	nplurals int
+	plural   pluralFunc
+
+	// byCtx записи по msgctxt
+	byCtx map[string]*entry
+	// byKey записи по msgctxt "\x04" msgid
+	byKey map[string]*entry
+}
+
+// Parse разберет .po-файл из r.
+func Parse(r io.Reader) (*Catalog, error) {
+	entries, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{
+		nplurals: 2,
+		plural:   germanic,
+		byCtx:    make(map[string]*entry),
+		byKey:    make(map[string]*entry),
+	}
+
+	for _, e := range entries {
+		switch {
+		case e.obsolete:
+			continue
+		case e.ctx == "" && e.id == "":
+			if len(e.strs) > 0 {
+				if err := c.parseHeader(e.strs[0]); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		case e.fuzzy || !translated(e):
+			continue
+		}
+
+		c.byKey[key(e.ctx, e.id)] = e
+		if e.ctx != "" {
+			if _, ok := c.byCtx[e.ctx]; !ok {
+				c.byCtx[e.ctx] = e
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// ParseFS разберет .po-файл path из fsys.
+func ParseFS(fsys fs.FS, path string) (*Catalog, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := Parse(f)
+	if err != nil {
+		return nil, errors.Wrap(errors.NewWith(
+			errors.SetMsg("po: parse failed"),
+			errors.AppendContextInfo("file", path),
+		), err)
+	}
+	return c, nil
+}
+
+// Language вернет язык каталога из заголовка Language.
+func (c *Catalog) Language() string {
+	return c.language
+}
+
+// Len вернет число переведенных сообщений.
+func (c *Catalog) Len() int {
+	return len(c.byKey)
+}
+
+// Localize вернет перевод сообщения conf.MessageID (см. описание пакета).
+// Если перевод не найден, но задано conf.DefaultMessage, вернется сообщение по-умолчанию
+// и ErrMessageNotFound.
+func (c *Catalog) Localize(conf *i18n.LocalizeConfig) (string, error) {
+	id := conf.MessageID
+	if id == "" && conf.DefaultMessage != nil {
+		id = conf.DefaultMessage.ID
+	}
+
+	data := conf.TemplateData
+	if data == nil && conf.PluralCount != nil {
+		data = map[string]interface{}{"PluralCount": conf.PluralCount}
+	}
+
+	e := c.lookup(id)
+	if e == nil {
+		if conf.DefaultMessage != nil {
+			msg, err := render(defaultForm(conf.DefaultMessage, conf.PluralCount), data)
+			if err != nil {
+				return "", err
+			}
+			return msg, ErrMessageNotFound.WithOptions(errors.AppendContextInfo("id", id))
+		}
+		return "", ErrMessageNotFound.WithOptions(errors.AppendContextInfo("id", id))
+	}
+
+	form := e.strs[0]
+	if e.idPlural != "" && conf.PluralCount != nil {
+		n, err := pluralCount(conf.PluralCount)
+		if err != nil {
+			return "", err
+		}
+		idx := c.plural(n)
+		if idx < 0 || idx >= int64(c.nplurals) || idx >= int64(len(e.strs)) || e.strs[idx] == "" {
+			idx = 0
+		}
+		form = e.strs[idx]
+	}
+
+	return render(form, data)
+}
+
+func (c *Catalog) lookup(id string) *entry {
+	if e, ok := c.byCtx[id]; ok {
+		return e
+	}
+	return c.byKey[key("", id)]
+}
+
+// Catalogs каталоги переводов в порядке убывания приоритета.
+// Перевод ищется в каждом каталоге по очереди.
+type Catalogs []*Catalog
+
+var _ errors.Localizer = (Catalogs)(nil)
+
+// Localize вернет перевод из первого каталога, содержащего сообщение.
+// Если сообщения нет ни в одном каталоге, результат как у Catalog.Localize последнего каталога.
+func (cs Catalogs) Localize(conf *i18n.LocalizeConfig) (string, error) {
+	for _, c := range cs {
+		if e := c.lookup(localizeID(conf)); e != nil {
+			return c.Localize(conf)
+		}
+	}
+	if len(cs) > 0 {
+		return cs[len(cs)-1].Localize(conf)
+	}
+	return "", ErrMessageNotFound
+}
+
+func localizeID(conf *i18n.LocalizeConfig) string {
+	if conf.MessageID == "" && conf.DefaultMessage != nil {
+		return conf.DefaultMessage.ID
+	}
+	return conf.MessageID
+}
+
+// parseHeader разберет заголовок (msgstr записи с пустым msgid).
+func (c *Catalog) parseHeader(header string) error {
+	for _, line := range strings.Split(header, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(name) {
+		case "Language":
+			c.language = value
+		case "Plural-Forms":
+			if err := c.parsePluralForms(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parsePluralForms разберет "nplurals=3; plural=(n%10==1 ? 0 : ...);".
+func (c *Catalog) parsePluralForms(value string) error {
+	for _, part := range strings.Split(value, ";") {
+		name, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+
+		switch strings.TrimSpace(name) {
+		case "nplurals":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return errors.InputBodyErrWith(
+					errors.SetMsg("po: invalid nplurals"),
+					errors.AppendContextInfo("value", v),
+				)
+			}
+			c.nplurals = n
+		case "plural":
+			fn, err := parsePlural(v)
+			if err != nil {
+				return err
+			}
+			c.plural = fn
+		}
+	}
+	return nil
+}
+
+func translated(e *entry) bool {
+	for _, s := range e.strs {
+		if s != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultForm вернет форму сообщения по-умолчанию для count (правила английского языка).
+func defaultForm(msg *i18n.Message, count interface{}) string {
+	if count != nil && msg.One != "" {
+		if n, err := pluralCount(count); err == nil && n == 1 {
+			return msg.One
+		}
+	}
+	return msg.Other
+}
+
+// pluralCount приведет PluralCount к целому числу.
+func pluralCount(count interface{}) (int64, error) {
+	switch v := count.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float32:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n, nil
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return int64(f), nil
+		}
+	}
+	return 0, errors.InputBodyErrWith(
+		errors.SetMsg("po: invalid plural count"),
+		errors.AppendContextInfo("count", count),
+	)
+}
+
+// render подставит data в шаблон text/template s.
+func render(s string, data interface{}) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+
+	tmpl, err := template.New("").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
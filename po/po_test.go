@@ -0,0 +1,146 @@
+package po
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/ovsinc/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const testPO = `# Russian translations
+msgid ""
+msgstr ""
+"Language: ru\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : "
+"n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+msgid "ErrNotFound"
+msgstr "не найдено"
+
+#. контекст -- ID ошибки, msgid -- исходное сообщение
+msgctxt "ErrUserNotFound"
+msgid "user {{.Name}} not found"
+msgstr "пользователь {{.Name}} не найден"
+
+msgctxt "ErrFiles"
+msgid "{{.PluralCount}} file"
+msgid_plural "{{.PluralCount}} files"
+msgstr[0] "{{.PluralCount}} файл"
+msgstr[1] "{{.PluralCount}} файла"
+msgstr[2] "{{.PluralCount}} файлов"
+
+#, fuzzy
+msgid "ErrFuzzy"
+msgstr "неточно"
+
+msgid "ErrEmpty"
+msgstr ""
+
+#~ msgid "ErrObsolete"
+#~ msgstr "устарело"
+`
+
+func TestParse(t *testing.T) {
+	c, err := Parse(strings.NewReader(testPO))
+	require.NoError(t, err)
+	require.Equal(t, "ru", c.Language())
+	require.Equal(t, 3, c.Len())
+
+	msg, err := c.Localize(&i18n.LocalizeConfig{MessageID: "ErrNotFound"})
+	require.NoError(t, err)
+	require.Equal(t, "не найдено", msg)
+
+	msg, err = c.Localize(&i18n.LocalizeConfig{
+		MessageID:    "ErrUserNotFound",
+		TemplateData: map[string]interface{}{"Name": "John"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "пользователь John не найден", msg)
+
+	for count, want := range map[interface{}]string{
+		1: "1 файл", 3: "3 файла", int64(5): "5 файлов", "11": "11 файлов", 21.0: "21 файл",
+	} {
+		msg, err = c.Localize(&i18n.LocalizeConfig{MessageID: "ErrFiles", PluralCount: count})
+		require.NoError(t, err)
+		require.Equal(t, want, msg)
+	}
+
+	for _, id := range []string{"ErrFuzzy", "ErrEmpty", "ErrObsolete", "ErrUnknown"} {
+		msg, err = c.Localize(&i18n.LocalizeConfig{MessageID: id})
+		require.True(t, errors.ContainsByID(err, ErrMessageNotFound.ID()), id)
+		require.Empty(t, msg)
+	}
+
+	msg, err = c.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{ID: "ErrUnknown", One: "one item", Other: "{{.PluralCount}} items"},
+		PluralCount:    2,
+	})
+	require.True(t, errors.ContainsByID(err, ErrMessageNotFound.ID()))
+	require.Equal(t, "2 items", msg)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		`msgid "unterminated`,
+		`"orphan string"`,
+		`msgfoo "x"`,
+		"msgid \"\"\nmsgstr \"Plural-Forms: nplurals=2; plural=(n > ;\\n\"",
+	} {
+		_, err := Parse(strings.NewReader(src))
+		require.Error(t, err, src)
+		require.Equal(t, errors.InputBody, errors.Classify(err), src)
+	}
+}
+
+func TestParsePlural(t *testing.T) {
+	fn, err := parsePlural("n==1 ? 0 : n==2 ? 1 : (n>=3 && n<=10) ? 2 : 3")
+	require.NoError(t, err)
+	for n, want := range map[int64]int64{1: 0, 2: 1, 3: 2, 10: 2, 11: 3, 0: 3} {
+		require.Equal(t, want, fn(n), n)
+	}
+
+	fn, err = parsePlural("!(n % 10) + 1 - 1 * 2 / 2")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), fn(20))
+	require.Equal(t, int64(0), fn(21))
+
+	for _, expr := range []string{"", "n ?", "n ? 1", "(n", "n n", "x"} {
+		_, err = parsePlural(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	c, err := ParseFS(fstest.MapFS{"ru.po": &fstest.MapFile{Data: []byte(testPO)}}, "ru.po")
+	require.NoError(t, err)
+
+	e := errors.NewWith(
+		errors.SetID("ErrUserNotFound"),
+		errors.SetMsg("user not found"),
+		errors.SetTemplateData(map[string]interface{}{"Name": "John"}),
+	)
+	msg, err := errors.Translate(e, c, nil)
+	require.NoError(t, err)
+	require.Equal(t, "пользователь John не найден", msg)
+
+	en, err := Parse(strings.NewReader("msgid \"ErrNotFound\"\nmsgstr \"not found\"\n"))
+	require.NoError(t, err)
+	msg, err = errors.Translate(errors.NewWith(errors.SetID("ErrNotFound")), Catalogs{en, c}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "not found", msg)
+
+	msg, err = errors.Translate(e, Catalogs{en, c}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "пользователь John не найден", msg)
+
+	_, err = ParseFS(fstest.MapFS{"bad.po": &fstest.MapFile{Data: []byte(`msgfoo ""`)}}, "bad.po")
+	merr, ok := err.(errors.Multierror) //nolint:errorlint
+	require.True(t, ok)
+	head, ok := merr.Errors()[0].(*errors.Error) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, "po: parse failed", head.Msg())
+	require.Equal(t, errors.InputBody, errors.Classify(merr.Errors()[1]))
+}
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// PseudoMode режим псевдолокализации, набор флагов.
+type PseudoMode uint32
+
+// PseudoOff псевдолокализация выключена.
+const PseudoOff PseudoMode = 0
+
+const (
+	// PseudoAccents заменит латинские буквы на буквы с диакритикой: "Error" -> "Éŕŕóŕ".
+	PseudoAccents PseudoMode = 1 << iota
+	// PseudoBrackets обрамит сообщение скобками: "[Error]".
+	PseudoBrackets
+	// PseudoExpand удлинит сообщение на 30% для проверки верстки: "Error ~~".
+	PseudoExpand
+	// PseudoMessageID вернет ID сообщения вместо перевода.
+	PseudoMessageID
+)
+
+// PseudoDefault режим псевдолокализации по-умолчанию.
+const PseudoDefault = PseudoAccents | PseudoBrackets | PseudoExpand
+
+var _pseudoMode uint32 //nolint:gochecknoglobals
+
+// SetPseudoLocalization включит псевдолокализацию mode для всех переводов (см. Translate).
+// PseudoOff выключит псевдолокализацию.
+func SetPseudoLocalization(mode PseudoMode) {
+	atomic.StoreUint32(&_pseudoMode, uint32(mode))
+}
+
+// PseudoLocalization вернет глобальный режим псевдолокализации (см. SetPseudoLocalization).
+func PseudoLocalization() PseudoMode {
+	return PseudoMode(atomic.LoadUint32(&_pseudoMode))
+}
+
+type pseudoCtxKey struct{}
+
+// WithPseudoLocalization вернет копию ctx с режимом псевдолокализации mode.
+// Используется в TranslateCtx, переопределяет глобальный режим.
+func WithPseudoLocalization(ctx context.Context, mode PseudoMode) context.Context {
+	return context.WithValue(ctx, pseudoCtxKey{}, mode)
+}
+
+// PseudoLocalizationFrom вернет режим псевдолокализации из ctx (см. WithPseudoLocalization).
+// Если режим в ctx не задан, вернется PseudoOff.
+func PseudoLocalizationFrom(ctx context.Context) PseudoMode {
+	if ctx == nil {
+		return PseudoOff
+	}
+	mode, _ := ctx.Value(pseudoCtxKey{}).(PseudoMode)
+	return mode
+}
+
+// PseudoLocalizer локализатор, преобразующий перевод локализатора Localizer согласно Mode.
+// Позволяет увидеть сообщения, не прошедшие через перевод,
+// и проблемы верстки с длинными сообщениями.
+// Если Localizer не задан или перевода нет, используется сообщение по-умолчанию (DefaultMessage).
+type PseudoLocalizer struct {
+	Localizer Localizer
+	Mode      PseudoMode
+}
+
+var _ Localizer = (*PseudoLocalizer)(nil)
+
+// NewPseudoLocalizer конструктор *PseudoLocalizer.
+func NewPseudoLocalizer(l Localizer, mode PseudoMode) *PseudoLocalizer {
+	return &PseudoLocalizer{Localizer: l, Mode: mode}
+}
+
+func (p *PseudoLocalizer) Localize(conf *i18n.LocalizeConfig) (string, error) {
+	id := conf.MessageID
+	if id == "" && conf.DefaultMessage != nil {
+		id = conf.DefaultMessage.ID
+	}
+
+	var (
+		msg string
+		err error = ErrNoLocalizer
+	)
+	if p.Localizer != nil {
+		msg, err = p.Localizer.Localize(conf)
+	}
+	if msg == "" && conf.DefaultMessage != nil {
+		msg, err = localizeMessage(nil, conf.DefaultMessage, conf.TemplateData, conf.PluralCount), nil
+	}
+	if msg == "" && p.Mode&PseudoMessageID == 0 {
+		return "", err
+	}
+
+	return p.Pseudo(msg, id), err
+}
+
+// Pseudo вернет сообщение msg с идентификатором id, преобразованное согласно Mode.
+func (p *PseudoLocalizer) Pseudo(msg, id string) string {
+	if p.Mode&PseudoMessageID != 0 {
+		return id
+	}
+
+	if p.Mode&PseudoAccents != 0 {
+		msg = strings.Map(pseudoAccent, msg)
+	}
+	if p.Mode&PseudoExpand != 0 {
+		if n := (utf8.RuneCountInString(msg)*3 + 9) / 10; n > 0 {
+			msg += " " + strings.Repeat("~", n)
+		}
+	}
+	if p.Mode&PseudoBrackets != 0 {
+		msg = "[" + msg + "]"
+	}
+	return msg
+}
+
+// pseudoWrap обернет l в *PseudoLocalizer, если включена псевдолокализация.
+func pseudoWrap(l Localizer, mode PseudoMode) Localizer {
+	if mode == PseudoOff {
+		return l
+	}
+	if _, ok := l.(*PseudoLocalizer); ok {
+		return l
+	}
+	return &PseudoLocalizer{Localizer: l, Mode: mode}
+}
+
+var _pseudoAccents = map[rune]rune{ //nolint:gochecknoglobals
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ď', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'í',
+	'j': 'ĵ', 'k': 'ķ', 'l': 'ĺ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ó', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ',
+	's': 'š', 't': 'ţ', 'u': 'ú', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Á', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ď', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Í',
+	'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ĺ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ó', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ',
+	'S': 'Š', 'T': 'Ţ', 'U': 'Ú', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+func pseudoAccent(r rune) rune {
+	if a, ok := _pseudoAccents[r]; ok {
+		return a
+	}
+	return r
+}
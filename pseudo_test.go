@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"context"
+	origerrors "errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPseudoLocalizer(t *testing.T) {
+	en := i18n.NewLocalizer(testBundle(), "en")
+	e := NewWith(SetID("ErrHello"), SetMsg("fallback"))
+
+	tests := []struct {
+		name string
+		l    Localizer
+		mode PseudoMode
+		want string
+	}{
+		{"default", en, PseudoDefault, "[ĥéĺĺó ~~]"},
+		{"accents", en, PseudoAccents, "ĥéĺĺó"},
+		{"brackets", en, PseudoBrackets, "[hello]"},
+		{"expand", en, PseudoExpand, "hello ~~"},
+		{"message id", en, PseudoMessageID, "ErrHello"},
+		{"no localizer", nil, PseudoBrackets, "[fallback]"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := Translate(e, NewPseudoLocalizer(tt.l, tt.mode), nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, msg)
+		})
+	}
+
+	msg, err := NewPseudoLocalizer(nil, PseudoBrackets).Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{ID: "ErrCount", Other: "{{.Count}} errors"},
+		TemplateData:   map[string]interface{}{"Count": 3},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "[3 errors]", msg)
+}
+
+func TestPseudoLocalizationToggle(t *testing.T) {
+	e := NewWith(SetID("ErrHello"), SetMsg("fallback"))
+
+	SetPseudoLocalization(PseudoBrackets)
+	defer SetPseudoLocalization(PseudoOff)
+
+	require.Equal(t, "[fallback]", DefaultTranslate(e))
+	require.Equal(t, "[fallback]", fmt.Sprintf("%+s", e))
+	// Error() не использует перевод
+	require.Equal(t, "fallback", e.Error())
+
+	msgs := TranslateAll(Combine(e, origerrors.New("plain")), nil)
+	require.Equal(t, []string{"[fallback]", "plain"}, msgs)
+
+	SetPseudoLocalization(PseudoOff)
+	ctx := WithPseudoLocalization(context.Background(), PseudoMessageID)
+	msg, err := TranslateCtx(ctx, e, nil)
+	require.NoError(t, err)
+	require.Equal(t, "ErrHello", msg)
+
+	ctx = WithLocalizer(ctx, i18n.NewLocalizer(testBundle(), "ru"))
+	ctx = WithPseudoLocalization(ctx, PseudoAccents)
+	msg, err = TranslateCtx(ctx, e, nil)
+	require.NoError(t, err)
+	require.Equal(t, "привет", msg)
+
+	msg, err = TranslateCtx(context.Background(), e, nil)
+	require.ErrorIs(t, err, ErrNoLocalizer)
+	require.Equal(t, "fallback", msg)
+}
+
+func TestPseudoLocalizationHTTP(t *testing.T) {
+	e := NewWith(SetID("ErrHello"), SetMsg("fallback"))
+	ctx := WithPseudoLocalization(context.Background(), PseudoBrackets)
+
+	w := httptest.NewRecorder()
+	WriteHTTPCtx(ctx, w, e)
+	require.Contains(t, w.Body.String(), `"msg":"[fallback]"`)
+
+	w = httptest.NewRecorder()
+	WriteHTTPProblemCtx(ctx, w, e)
+	require.Contains(t, w.Body.String(), `"detail":"[fallback]"`)
+
+	ctx = WithLocalizer(ctx, i18n.NewLocalizer(testBundle(), "ru"))
+	w = httptest.NewRecorder()
+	WriteHTTPCtx(ctx, w, e)
+	require.Contains(t, w.Body.String(), `"msg":"[привет]"`)
+}
@@ -0,0 +1,38 @@
+package errors
+
+// Resolver выбирает из дерева ошибок ту, которую следует показать пользователю.
+// Правила проверяются в порядке приоритета: будет выбрана ошибка,
+// найденная по первому подходящему правилу.
+type Resolver struct {
+	matchers []Matcher
+	fallback *Error
+}
+
+// NewResolver конструктор *Resolver.
+// * fallback *Error -- ошибка, которая будет возвращена, если ни одно правило не подошло.
+// * matchers ...Matcher -- правила в порядке убывания приоритета.
+func NewResolver(fallback *Error, matchers ...Matcher) *Resolver {
+	return &Resolver{
+		matchers: matchers,
+		fallback: fallback,
+	}
+}
+
+// Resolve вернет ошибку дерева err, найденную по наиболее приоритетному правилу.
+// Если найденная ошибка не *Error, она будет преобразована с помощью Cast.
+// Если ни одно правило не подошло, вернется fallback и false.
+// Для err == nil, вернется nil и false.
+func (r *Resolver) Resolve(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	for _, m := range r.matchers {
+		if found := Find(err, m); found != nil {
+			e, _ := Cast(found)
+			return e, true
+		}
+	}
+
+	return r.fallback, false
+}
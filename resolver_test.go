@@ -0,0 +1,74 @@
+package errors
+
+import (
+	origerrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	errSentinel := origerrors.New("sentinel")
+
+	eBadContent := InputBodyErrWith(SetID("ErrBadContent"), SetMsg("bad content"))
+	eNotFound := NotFoundErrWith(SetID("ErrNotFound"), SetMsg("not found"))
+	eInternal := IternalErrWith(SetID("ErrInternal"), SetMsg("internal"))
+	eFallback := IternalErrWith(SetID("ErrUnknown"), SetMsg("unknown"))
+
+	r := NewResolver(
+		eFallback,
+		MatchID("ErrBadContent"),
+		MatchErr(errSentinel),
+		MatchType(NotFound),
+		MatchID("ErrInternal"),
+	)
+
+	tests := []struct {
+		name  string
+		err   error
+		want  *Error
+		found bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:  "single",
+			err:   eNotFound,
+			want:  eNotFound,
+			found: true,
+		},
+		{
+			name:  "priority",
+			err:   Combine(eInternal, fmt.Errorf("wrapped: %w", eNotFound), eBadContent),
+			want:  eBadContent,
+			found: true,
+		},
+		{
+			name:  "type",
+			err:   Combine(eInternal, NotFoundErr("other")),
+			want:  NotFoundErr("other"),
+			found: true,
+		},
+		{
+			name:  "sentinel",
+			err:   Combine(eInternal, fmt.Errorf("wrapped: %w", errSentinel)),
+			want:  New(errSentinel),
+			found: true,
+		},
+		{
+			name: "fallback",
+			err:  Combine(origerrors.New("one"), New("two")),
+			want: eFallback,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := r.Resolve(tt.err)
+			require.Equal(t, tt.found, found)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
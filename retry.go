@@ -0,0 +1,166 @@
+package errors
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+type retryState uint8
+
+const (
+	retryDefault retryState = iota
+	retryYes
+	retryNo
+)
+
+// retryableType вернет признак повторяемости по-умолчанию для типа ошибки.
+func retryableType(et IErrType) bool {
+	switch et {
+	case Unavailable, DownstreamDependencyTimedout:
+		return true
+	}
+	return false
+}
+
+// primaryErr вернет *Error, описывающую ошибку err (например, ее повторяемость):
+// для Multierror -- выбранную DefaultAggregatePolicy, иначе -- первую *Error в цепочке.
+func primaryErr(err error) *Error {
+	if _, ok := err.(Multierror); ok { //nolint:errorlint
+		return aggregate(err)
+	}
+	e, _ := Find(err, isError).(*Error) //nolint:errorlint
+	return e
+}
+
+// IsRetryable сообщит, может ли повтор операции, завершившейся ошибкой err, быть успешным.
+// Решение принимается по *Error из цепочки (см. Error.Retryable),
+// для прочих ошибок -- по типу, определенному Classify.
+// Операция, отмененная вызывающей стороной (context.Canceled), не повторяется.
+// Для err == nil вернется false.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if e := primaryErr(err); e != nil {
+		return e.Retryable()
+	}
+	if ContainsByErr(err, context.Canceled) {
+		return false
+	}
+	return retryableType(Classify(err))
+}
+
+// RetryAfter вернет рекомендуемую задержку перед повтором операции:
+// наибольшую из задержек *Error в дереве ошибок err.
+func RetryAfter(err error) time.Duration {
+	var d time.Duration
+	Walk(err, func(e error, _ int) bool {
+		if ee, ok := e.(*Error); ok && ee.RetryAfter() > d { //nolint:errorlint
+			d = ee.RetryAfter()
+		}
+		return false
+	})
+	return d
+}
+
+// RetryPolicy параметры повтора операции с экспоненциальной задержкой.
+type RetryPolicy struct {
+	// MaxAttempts максимальное число попыток.
+	MaxAttempts int
+	// InitialInterval задержка перед второй попыткой.
+	InitialInterval time.Duration
+	// MaxInterval максимальная задержка между попытками.
+	MaxInterval time.Duration
+	// Multiplier множитель задержки для каждой следующей попытки.
+	Multiplier float64
+	// Jitter доля случайного отклонения задержки, от 0 до 1.
+	Jitter float64
+}
+
+// DefaultRetryPolicy параметры повтора по-умолчанию.
+var DefaultRetryPolicy = RetryPolicy{ //nolint:gochecknoglobals
+	MaxAttempts:     3,
+	InitialInterval: 100 * time.Millisecond,
+	MaxInterval:     10 * time.Second,
+	Multiplier:      2,
+	Jitter:          0.2,
+}
+
+// ErrMaximumAttempts исчерпано число попыток выполнения операции.
+// Retry возвращает копию ошибки с числом попыток в контексте (ключ "attempts"),
+// поэтому проверять ее нужно по ID: ContainsByID(err, ErrMaximumAttempts.ID()).
+var ErrMaximumAttempts = MaximumAttemptsErrWith(SetID("ErrMaximumAttempts"), SetMsg("maximum attempts exceeded"))
+
+// Retry выполнит fn, повторяя вызов при ошибках, для которых IsRetryable вернет true.
+// Задержка между попытками растет экспоненциально со случайным отклонением,
+// но не меньше RetryAfter ошибки.
+//
+// Ошибки всех попыток объединяются в Multierror:
+// * если ошибка не повторяемая, вернутся ошибки выполненных попыток;
+// * если попытки исчерпаны, первой в цепочке будет ErrMaximumAttempts;
+// * если контекст отменен, последней в цепочке будет ctx.Err().
+// Если очередная попытка успешна, вернется nil.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+
+	errs := make([]error, 0, policy.MaxAttempts)
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+
+		if !IsRetryable(err) {
+			return Combine(errs...)
+		}
+
+		if attempt >= policy.MaxAttempts {
+			return Wrap(
+				ErrMaximumAttempts.WithOptions(AppendContextInfo("attempts", attempt)),
+				Combine(errs...),
+			)
+		}
+
+		delay := policy.backoff(attempt)
+		if d := RetryAfter(err); d > delay {
+			delay = d
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			errs = append(errs, ctx.Err())
+			return Combine(errs...)
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff вернет задержку после попытки attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialInterval)
+	for i := 1; i < attempt; i++ {
+		d *= p.Multiplier
+		if p.MaxInterval > 0 && d > float64(p.MaxInterval) {
+			d = float64(p.MaxInterval)
+			break
+		}
+	}
+
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1) //nolint:gosec
+	}
+	if p.MaxInterval > 0 && d > float64(p.MaxInterval) {
+		d = float64(p.MaxInterval)
+	}
+	if d < 0 {
+		d = 0
+	}
+
+	return time.Duration(d)
+}
@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"context"
+	origerrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"unavailable", UnavailableErr("down"), true},
+		{"timeout", DownstreamDependencyTimedoutErr("slow"), true},
+		{"validation", ValidationErr("bad"), false},
+		{"override", ValidationErrWith(SetRetryable(true)), true},
+		{"override no", UnavailableErrWith(SetRetryable(false)), false},
+		{"retry after", MaximumAttemptsErrWith(SetRetryAfter(time.Second)), true},
+		{"wrapped", fmt.Errorf("call: %w", UnavailableErr("down")), true},
+		{"std", origerrors.New("std"), false},
+		{"classified", fmt.Errorf("call: %w", context.DeadlineExceeded), true},
+		{"canceled", fmt.Errorf("call: %w", context.Canceled), false},
+		{"canceled multi", Combine(context.Canceled, context.DeadlineExceeded), false},
+		{"multi", Combine(origerrors.New("std"), UnavailableErr("down")), true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	err := Combine(
+		UnavailableErrWith(SetRetryAfter(time.Second)),
+		fmt.Errorf("call: %w", UnavailableErrWith(SetRetryAfter(2*time.Second))),
+	)
+	require.Equal(t, 2*time.Second, RetryAfter(err))
+	require.Equal(t, time.Duration(0), RetryAfter(origerrors.New("std")))
+}
+
+var testRetryPolicy = RetryPolicy{ //nolint:gochecknoglobals
+	MaxAttempts:     3,
+	InitialInterval: time.Millisecond,
+	MaxInterval:     5 * time.Millisecond,
+	Multiplier:      2,
+	Jitter:          0.5,
+}
+
+func TestRetry(t *testing.T) {
+	var calls int
+	err := Retry(context.Background(), testRetryPolicy, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return UnavailableErr("down")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var calls int
+	err := Retry(context.Background(), testRetryPolicy, func(context.Context) error {
+		calls++
+		return UnavailableErr("down")
+	})
+	require.Equal(t, 3, calls)
+
+	merr, ok := err.(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, 4, merr.Len())
+
+	et, _ := GetErrType(merr.Last())
+	require.Equal(t, MaximumAttempts, et)
+	require.Equal(t, CtxKV{{"attempts", 3}}, merr.Last().(*Error).ContextInfo()) //nolint:errorlint,forcetypeassert
+
+	require.True(t, ContainsByID(err, ErrMaximumAttempts.ID()))
+	require.False(t, ContainsByID(UnavailableErr("down"), ErrMaximumAttempts.ID()))
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	var calls int
+	eValidation := ValidationErr("bad")
+	err := Retry(context.Background(), testRetryPolicy, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return UnavailableErr("down")
+		}
+		return eValidation
+	})
+	require.Equal(t, 2, calls)
+
+	merr, ok := err.(Multierror) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, 2, merr.Len())
+	require.Equal(t, eValidation, merr.Errors()[1])
+}
+
+func TestRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	policy := testRetryPolicy
+	policy.InitialInterval = time.Hour
+	policy.MaxInterval = time.Hour
+
+	err := Retry(ctx, policy, func(context.Context) error {
+		cancel()
+		return UnavailableErr("down")
+	})
+	require.True(t, ContainsByErr(err, context.Canceled))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     time.Second,
+		Multiplier:      2,
+	}
+	require.Equal(t, 100*time.Millisecond, p.backoff(1))
+	require.Equal(t, 200*time.Millisecond, p.backoff(2))
+	require.Equal(t, 800*time.Millisecond, p.backoff(4))
+	require.Equal(t, time.Second, p.backoff(10))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.backoff(2)
+		require.GreaterOrEqual(t, d, 100*time.Millisecond)
+		require.LessOrEqual(t, d, 300*time.Millisecond)
+	}
+}
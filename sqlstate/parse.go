@@ -0,0 +1,100 @@
+package sqlstate
+
+import (
+	"reflect"
+)
+
+// parse извлечет сведения об ошибке базы данных из err (без обхода цепочки).
+func parse(err error) (Info, bool) {
+	var info Info
+
+	if s, ok := err.(interface{ SQLState() string }); ok { //nolint:errorlint
+		info.SQLState = s.SQLState()
+	}
+	if c, ok := err.(interface{ ConstraintName() string }); ok { //nolint:errorlint
+		info.Constraint = c.ConstraintName()
+	}
+	if t, ok := err.(interface{ TableName() string }); ok { //nolint:errorlint
+		info.Table = t.TableName()
+	}
+
+	parseFields(err, &info)
+
+	return info, isSQLState(info.SQLState) || info.Number > 0
+}
+
+// parseFields заполнит незаполненные поля info по полям структуры err.
+func parseFields(err error, info *Info) {
+	v := reflect.ValueOf(err)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	if info.SQLState == "" {
+		info.SQLState = stringField(v, "SQLState", "Code")
+	}
+	if info.Constraint == "" {
+		info.Constraint = stringField(v, "ConstraintName", "Constraint")
+	}
+	if info.Table == "" {
+		info.Table = stringField(v, "TableName", "Table")
+	}
+	if f := v.FieldByName("Number"); f.IsValid() && f.CanInterface() {
+		switch f.Kind() { //nolint:exhaustive
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			info.Number = int(f.Uint())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			info.Number = int(f.Int())
+		}
+	}
+}
+
+// stringField вернет значение первого подходящего поля из names.
+// Поддерживаются строковые поля и массивы байт (например, [5]byte в MySQL).
+func stringField(v reflect.Value, names ...string) string {
+	for _, name := range names {
+		f := v.FieldByName(name)
+		if !f.IsValid() || !f.CanInterface() {
+			continue
+		}
+
+		switch f.Kind() { //nolint:exhaustive
+		case reflect.String:
+			if s := f.String(); s != "" {
+				return s
+			}
+		case reflect.Array:
+			if f.Type().Elem().Kind() != reflect.Uint8 {
+				continue
+			}
+			b := make([]byte, f.Len())
+			for i := range b {
+				b[i] = byte(f.Index(i).Uint())
+			}
+			if s := string(b); s != string(make([]byte, len(b))) {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// isSQLState проверит, что s -- код SQLSTATE: 5 символов из цифр и заглавных латинских букв.
+func isSQLState(s string) bool {
+	if len(s) != 5 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
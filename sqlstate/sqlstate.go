@@ -0,0 +1,162 @@
+// Package sqlstate классификация ошибок баз данных по SQLSTATE и кодам ошибок MySQL
+// без зависимости от драйверов.
+//
+// Ошибки драйверов распознаются по наличию методов (SQLState() string,
+// ConstraintName() string, TableName() string) или полей структуры
+// (Code, SQLState, Number, ConstraintName/Constraint, TableName/Table),
+// что покрывает github.com/jackc/pgx, github.com/lib/pq и github.com/go-sql-driver/mysql.
+package sqlstate
+
+import (
+	"github.com/ovsinc/errors"
+)
+
+// Ключи контекста *Error.
+const (
+	KeySQLState   = "sqlstate"
+	KeyConstraint = "constraint"
+	KeyTable      = "table"
+)
+
+// Info сведения об ошибке базы данных.
+type Info struct {
+	// SQLState код SQLSTATE, например "23505".
+	SQLState string
+	// Number числовой код ошибки (MySQL).
+	Number int
+	// Constraint имя нарушенного ограничения.
+	Constraint string
+	// Table имя таблицы.
+	Table string
+}
+
+// Find вернет сведения о первой ошибке базы данных в дереве ошибок err.
+func Find(err error) (Info, bool) {
+	var (
+		info  Info
+		found bool
+	)
+	errors.Walk(err, func(e error, _ int) bool {
+		info, found = parse(e)
+		return found
+	})
+	return info, found
+}
+
+// Classify вернет тип ошибки базы данных из дерева ошибок err
+// и признак того, что повтор операции может быть успешным.
+// Если ошибка базы данных не найдена, вернется errors.Unknown и ok == false.
+func Classify(err error) (et errors.IErrType, retryable bool, ok bool) {
+	info, found := Find(err)
+	if !found {
+		return errors.Unknown, false, false
+	}
+	et, retryable = classify(info)
+	return et, retryable, true
+}
+
+// Rule правило для errors.Classifier.
+//
+//	errors.DefaultClassifier.Register(sqlstate.Rule)
+func Rule(err error) (errors.IErrType, bool) {
+	info, ok := parse(err)
+	if !ok {
+		return errors.Unknown, false
+	}
+	et, _ := classify(info)
+	return et, true
+}
+
+// Wrap обернет ошибку базы данных err в *Error с типом, определенным Classify.
+// В контекст *Error будут добавлены SQLSTATE, имена ограничения и таблицы (если известны),
+// признак повторяемости (см. errors.IsRetryable) устанавливается явно.
+// * ops ...errors.Options -- дополнительная параметризация *Error.
+// Если ошибка базы данных не найдена, err вернется без изменений.
+func Wrap(err error, ops ...errors.Options) error {
+	info, found := Find(err)
+	if !found {
+		return err
+	}
+
+	et, retryable := classify(info)
+
+	op := make([]errors.Options, 0, len(ops)+6)
+	op = append(op,
+		errors.SetMsg(err.Error()),
+		errors.SetErrorType(et),
+	)
+	if info.SQLState != "" {
+		op = append(op, errors.AppendContextInfo(KeySQLState, info.SQLState))
+	}
+	if info.Constraint != "" {
+		op = append(op, errors.AppendContextInfo(KeyConstraint, info.Constraint))
+	}
+	if info.Table != "" {
+		op = append(op, errors.AppendContextInfo(KeyTable, info.Table))
+	}
+	op = append(op, errors.SetRetryable(retryable))
+	op = append(op, ops...)
+
+	return errors.Wrap(errors.NewWith(op...), err)
+}
+
+func classify(info Info) (errors.IErrType, bool) {
+	if info.Number > 0 {
+		if et, retryable, ok := classifyMySQL(info.Number); ok {
+			return et, retryable
+		}
+	}
+	return classifySQLState(info.SQLState)
+}
+
+// classifySQLState см. https://www.postgresql.org/docs/current/errcodes-appendix.html
+func classifySQLState(state string) (errors.IErrType, bool) { //nolint:cyclop
+	switch state {
+	case "23505": // unique_violation
+		return errors.Duplicate, false
+	case "40001", "40P01": // serialization_failure, deadlock_detected
+		return errors.Internal, true
+	case "42501": // insufficient_privilege
+		return errors.Unauthorized, false
+	case "57014": // query_canceled
+		return errors.DownstreamDependencyTimedout, true
+	case "57P01", "57P02", "57P03": // admin_shutdown, crash_shutdown, cannot_connect_now
+		return errors.Unavailable, true
+	}
+
+	if len(state) < 2 {
+		return errors.Internal, false
+	}
+
+	switch state[:2] {
+	case "08": // connection exception
+		return errors.Unavailable, true
+	case "22", "23": // data exception, integrity constraint violation
+		return errors.Validation, false
+	case "28": // invalid authorization specification
+		return errors.Unauthenticated, false
+	case "53": // insufficient resources
+		return errors.Unavailable, true
+	}
+
+	return errors.Internal, false
+}
+
+// classifyMySQL см. https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+func classifyMySQL(number int) (errors.IErrType, bool, bool) {
+	switch number {
+	case 1062, 1586: // ER_DUP_ENTRY, ER_DUP_ENTRY_WITH_KEY_NAME
+		return errors.Duplicate, false, true
+	case 1451, 1452, 1048, 1364, 1406: // foreign key, not null, no default, data too long
+		return errors.Validation, false, true
+	case 1205, 1213: // ER_LOCK_WAIT_TIMEOUT, ER_LOCK_DEADLOCK
+		return errors.Internal, true, true
+	case 1044, 1142, 1143: // access denied
+		return errors.Unauthorized, false, true
+	case 1045: // ER_ACCESS_DENIED_ERROR
+		return errors.Unauthenticated, false, true
+	case 1040, 1053, 2002, 2003, 2006, 2013: // too many connections, shutdown, connection lost
+		return errors.Unavailable, true, true
+	}
+	return errors.Unknown, false, false
+}
@@ -0,0 +1,149 @@
+package sqlstate_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovsinc/errors"
+	"github.com/ovsinc/errors/sqlstate"
+)
+
+// pgError поля как у github.com/jackc/pgx/v5/pgconn.PgError.
+type pgError struct {
+	Message        string
+	Code           string
+	TableName      string
+	ConstraintName string
+}
+
+func (e *pgError) Error() string { return e.Message }
+
+func (e *pgError) SQLState() string { return e.Code }
+
+// pqError поля как у github.com/lib/pq.Error.
+type pqError struct {
+	Code       string
+	Message    string
+	Table      string
+	Constraint string
+}
+
+func (e pqError) Error() string { return e.Message }
+
+// mysqlError поля как у github.com/go-sql-driver/mysql.MySQLError.
+type mysqlError struct {
+	Number   uint16
+	SQLState [5]byte
+	Message  string
+}
+
+func (e *mysqlError) Error() string { return e.Message }
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		want      errors.IErrType
+		retryable bool
+		ok        bool
+	}{
+		{
+			name: "not db",
+			err:  stderrors.New("plain"),
+			want: errors.Unknown,
+		},
+		{
+			name: "pgx unique",
+			err:  fmt.Errorf("insert: %w", &pgError{Code: "23505"}),
+			want: errors.Duplicate,
+			ok:   true,
+		},
+		{
+			name: "pq foreign key",
+			err:  pqError{Code: "23503"},
+			want: errors.Validation,
+			ok:   true,
+		},
+		{
+			name:      "serialization failure",
+			err:       &pgError{Code: "40001"},
+			want:      errors.Internal,
+			retryable: true,
+			ok:        true,
+		},
+		{
+			name:      "connection exception",
+			err:       pqError{Code: "08006"},
+			want:      errors.Unavailable,
+			retryable: true,
+			ok:        true,
+		},
+		{
+			name: "mysql duplicate",
+			err:  &mysqlError{Number: 1062, SQLState: [5]byte{'2', '3', '0', '0', '0'}},
+			want: errors.Duplicate,
+			ok:   true,
+		},
+		{
+			name:      "mysql deadlock",
+			err:       &mysqlError{Number: 1213},
+			want:      errors.Internal,
+			retryable: true,
+			ok:        true,
+		},
+		{
+			name: "mysql unknown number",
+			err:  &mysqlError{Number: 9999, SQLState: [5]byte{'2', '3', '0', '0', '0'}},
+			want: errors.Validation,
+			ok:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			et, retryable, ok := sqlstate.Classify(tt.err)
+			require.Equal(t, tt.want, et)
+			require.Equal(t, tt.retryable, retryable)
+			require.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	dbErr := &pgError{
+		Message:        "duplicate key value violates unique constraint",
+		Code:           "23505",
+		TableName:      "users",
+		ConstraintName: "users_email_key",
+	}
+
+	err := sqlstate.Wrap(fmt.Errorf("insert: %w", dbErr), errors.SetOperation("storage.NewUser"))
+
+	e, ok := errors.Cast(err)
+	require.True(t, ok)
+	require.Equal(t, errors.Duplicate, e.ErrorType())
+	require.Equal(t, "storage.NewUser", e.Operation())
+	require.Equal(t, errors.CtxKV{
+		{Key: sqlstate.KeySQLState, Value: "23505"},
+		{Key: sqlstate.KeyConstraint, Value: "users_email_key"},
+		{Key: sqlstate.KeyTable, Value: "users"},
+	}, e.ContextInfo())
+	require.True(t, errors.ContainsByErr(err, dbErr))
+	require.False(t, errors.IsRetryable(err))
+
+	require.True(t, errors.IsRetryable(sqlstate.Wrap(&pgError{Code: "40001"})))
+
+	plain := stderrors.New("plain")
+	require.Equal(t, plain, sqlstate.Wrap(plain))
+}
+
+func TestRule(t *testing.T) {
+	c := errors.NewClassifier(sqlstate.Rule)
+
+	et, ok := c.Classify(fmt.Errorf("insert: %w", &pgError{Code: "23505"}))
+	require.True(t, ok)
+	require.Equal(t, errors.Duplicate, et)
+}
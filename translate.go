@@ -1,11 +1,14 @@
 package errors
 
 import (
+	"context"
+	"strings"
+
 	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
 // DefaultLocalizer локализатор по-умолчанию.
-// Для каждой ошибки можно переопределить локализатор.
+// Для каждой ошибки можно переопределить локализатор (см. SetLocalizer).
 var DefaultLocalizer Localizer //nolint:gochecknoglobals
 
 var (
@@ -34,38 +37,205 @@ func DefaultTranslate(e error) string {
 }
 
 // Translate вернет перевод сообщения ошибки.
+// Локализатор выбирается в порядке: l, локализатор ошибки (см. SetLocalizer), DefaultLocalizer.
+// Незаданные поля tctx берутся из контекста перевода ошибки (см. Error.TranslateContext).
 // Если не удастся выполнить перевод, вернет оригинальное сообщение.
+//
+// Для Multierror вернется многострочное представление (см. MarshalString)
+// с переводом каждой *Error, в том числе обернутой (например, fmt.Errorf("...: %w", err)).
+// Для прочих ошибок вернется текст цепочки, в котором первая *Error переведена,
+// а тексты оберток сохранены.
+// Контекст tctx применяется к каждой переводимой *Error.
+// Если *Error в цепочке нет, вернется e.Error() и ErrNotError.
 func Translate(e error, l Localizer, tctx *TranslateContext) (string, error) {
 	err, ok := e.(*Error) //nolint:errorlint
-	var loc Localizer
-
-	switch {
-	case !ok:
-		return e.Error(), ErrNotError
-
-	case l != nil:
-		loc = l
-
-	case DefaultLocalizer != nil:
-		loc = DefaultLocalizer
+	if !ok {
+		return translateChain(e, l, tctx)
+	}
 
-	default:
-		// no localizer
+	if l == nil {
+		l = err.localizer
+	}
+	loc := pseudoWrap(localizerOf(l), PseudoLocalization())
+	if loc == nil {
 		return err.Msg(), ErrNoLocalizer
 	}
 
-	i18nConf := i18n.LocalizeConfig{
-		MessageID: err.ID(),
-	}
+	errctx := err.TranslateContext()
 	if tctx != nil {
-		i18nConf.DefaultMessage = tctx.DefaultMessage
-		i18nConf.PluralCount = tctx.PluralCount
-		i18nConf.TemplateData = tctx.TemplateData
+		if tctx.DefaultMessage != nil {
+			errctx.DefaultMessage = tctx.DefaultMessage
+		}
+		if tctx.PluralCount != nil {
+			errctx.PluralCount = tctx.PluralCount
+		}
+		if tctx.TemplateData != nil {
+			errctx.TemplateData = tctx.TemplateData
+		}
+	}
+
+	i18nConf := i18n.LocalizeConfig{
+		MessageID:      err.ID(),
+		DefaultMessage: errctx.DefaultMessage,
+		PluralCount:    errctx.PluralCount,
+		TemplateData:   errctx.TemplateData,
 	}
 
 	if msg, err := loc.Localize(&i18nConf); err == nil {
 		return msg, nil
 	}
 
+	// сообщение прошло через перевод, хотя перевода нет
+	if p, ok := loc.(*PseudoLocalizer); ok {
+		return p.Pseudo(err.Msg(), err.ID()), nil
+	}
+
 	return err.Msg(), nil
 }
+
+// Localizer вернет локализатор ошибки (см. SetLocalizer).
+func (e *Error) Localizer() Localizer {
+	if e == nil {
+		return nil
+	}
+	return e.localizer
+}
+
+// TranslateContext вернет контекст перевода ошибки.
+// Если данные шаблона не заданы явно (см. SetTemplateData),
+// они формируются из контекста CtxKV ошибки.
+func (e *Error) TranslateContext() TranslateContext {
+	if e == nil {
+		return TranslateContext{}
+	}
+
+	tctx := TranslateContext{
+		TemplateData:   e.templateData,
+		PluralCount:    e.pluralCount,
+		DefaultMessage: e.defaultMessage,
+	}
+	if tctx.TemplateData == nil && len(e.contextInfo) > 0 {
+		tctx.TemplateData = make(map[string]interface{}, len(e.contextInfo))
+		for _, kv := range e.contextInfo {
+			tctx.TemplateData[kv.Key] = kv.Value
+		}
+	}
+	return tctx
+}
+
+// TranslateAll вернет переводы сообщений всех ошибок err по порядку.
+// Элементы Multierror и вложенных узлов дерева ошибок разворачиваются,
+// остальные ошибки переводятся с помощью Translate.
+func TranslateAll(err error, l Localizer) []string {
+	var msgs []string
+
+	var walk func(e error)
+	walk = func(e error) {
+		if e == nil {
+			return
+		}
+		if nested, ok := listErrors(e); ok {
+			for _, n := range nested {
+				walk(n)
+			}
+			return
+		}
+		msg, _ := Translate(e, l, nil)
+		msgs = append(msgs, msg)
+	}
+	walk(err)
+
+	return msgs
+}
+
+// translateChain вернет перевод цепочки ошибок e (см. Translate).
+// Тексты оберток сохраняются, переводятся только *Error.
+func translateChain(e error, l Localizer, tctx *TranslateContext) (string, error) {
+	if e == nil {
+		return "", ErrNotError
+	}
+
+	loc := pseudoWrap(localizerOf(l), PseudoLocalization())
+
+	var buf strings.Builder
+	if merr, ok := e.(Multierror); ok { //nolint:errorlint
+		stringMultierrFormat(&buf, merr, 0, loc, tctx)
+		if loc == nil {
+			return buf.String(), ErrNoLocalizer
+		}
+		return buf.String(), nil
+	}
+
+	inner, ok := Find(e, isError).(*Error) //nolint:errorlint
+	if !ok {
+		return e.Error(), ErrNotError
+	}
+
+	if loc == nil {
+		loc = inner.Localizer()
+	}
+	if loc == nil {
+		return e.Error(), ErrNoLocalizer
+	}
+	wrappedFormat(&buf, e, loc, tctx)
+	return buf.String(), nil
+}
+
+// localizedMsg вернет сообщение *Error для маршалеров: перевод с локализатором l
+// или с собственным локализатором ошибки (см. SetLocalizer), иначе Msg().
+// tctx -- контекст перевода (см. Translate), может быть nil.
+func localizedMsg(e *Error, l Localizer, tctx *TranslateContext) string {
+	if l == nil && e.Localizer() == nil {
+		return e.Msg()
+	}
+	msg, _ := Translate(e, l, tctx)
+	return msg
+}
+
+// localizerOf вернет локализатор для перевода: l, если он задан, иначе DefaultLocalizer.
+func localizerOf(l Localizer) Localizer {
+	if l != nil {
+		return l
+	}
+	return DefaultLocalizer
+}
+
+type localizerCtxKey struct{}
+
+// WithLocalizer вернет копию ctx с локализатором l.
+// Используется в TranslateCtx для перевода сообщений на язык запроса.
+func WithLocalizer(ctx context.Context, l Localizer) context.Context {
+	return context.WithValue(ctx, localizerCtxKey{}, l)
+}
+
+// LocalizerFrom вернет локализатор из ctx (см. WithLocalizer).
+// Если локализатор не задан, вернется nil.
+func LocalizerFrom(ctx context.Context) Localizer {
+	if ctx == nil {
+		return nil
+	}
+	l, _ := ctx.Value(localizerCtxKey{}).(Localizer)
+	return l
+}
+
+// TranslateCtx вернет перевод сообщения ошибки, как и Translate,
+// но с локализатором из ctx (см. WithLocalizer).
+// Если локализатор в ctx не задан, будет использован DefaultLocalizer.
+// Если для ctx включена псевдолокализация (см. WithPseudoLocalization), она будет применена к переводу.
+func TranslateCtx(ctx context.Context, e error, tctx *TranslateContext) (string, error) {
+	return Translate(e, localizerCtx(ctx, e), tctx)
+}
+
+// localizerCtx вернет локализатор из ctx для перевода ошибки e (см. LocalizerFrom).
+// Если для ctx включена псевдолокализация, локализатор (или собственный локализатор *Error,
+// или DefaultLocalizer) будет обернут в *PseudoLocalizer.
+func localizerCtx(ctx context.Context, e error) Localizer {
+	l := LocalizerFrom(ctx)
+	if mode := PseudoLocalizationFrom(ctx); mode != PseudoOff {
+		if err, ok := e.(*Error); ok && l == nil { //nolint:errorlint
+			l = err.localizer
+		}
+		l = pseudoWrap(localizerOf(l), mode)
+	}
+	return l
+}
@@ -0,0 +1,225 @@
+package errors
+
+import (
+	"context"
+	origerrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func testBundle() *i18n.Bundle {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.English, &i18n.Message{ID: "ErrHello", Other: "hello"})
+	bundle.MustAddMessages(language.Russian, &i18n.Message{ID: "ErrHello", Other: "привет"})
+	return bundle
+}
+
+func TestTranslateCtx(t *testing.T) {
+	e := NewWith(SetID("ErrHello"), SetMsg("fallback"))
+
+	msg, err := TranslateCtx(context.Background(), e, nil)
+	require.ErrorIs(t, err, ErrNoLocalizer)
+	require.Equal(t, "fallback", msg)
+
+	ctx := WithLocalizer(context.Background(), i18n.NewLocalizer(testBundle(), "ru"))
+	msg, err = TranslateCtx(ctx, e, nil)
+	require.NoError(t, err)
+	require.Equal(t, "привет", msg)
+}
+
+func TestLocalizerMiddleware(t *testing.T) {
+	bundle := testBundle()
+	mw := LocalizerMiddleware(func(langs ...string) Localizer {
+		return i18n.NewLocalizer(bundle, langs...)
+	})
+
+	var msg string
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg, _ = TranslateCtx(r.Context(), NewWith(SetID("ErrHello")), nil)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "ru-RU,ru;q=0.9,en;q=0.8")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	require.Equal(t, "привет", msg)
+
+	// параметр запроса не используется без явного разрешения
+	r = httptest.NewRequest(http.MethodGet, "/?lang=en", nil)
+	r.Header.Set("Accept-Language", "ru")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	require.Equal(t, "привет", msg)
+
+	h = LocalizerMiddleware(func(langs ...string) Localizer {
+		return i18n.NewLocalizer(bundle, langs...)
+	}, LangQueryParam)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg, _ = TranslateCtx(r.Context(), NewWith(SetID("ErrHello")), nil)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	require.Equal(t, "hello", msg)
+}
+
+func TestWriteHTTPCtx(t *testing.T) {
+	mw := LocalizerMiddleware(func(langs ...string) Localizer {
+		return i18n.NewLocalizer(testBundle(), langs...)
+	})
+	err := NotFoundErrWith(SetID("ErrHello"), SetMsg("hello"))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "ru")
+
+	w := httptest.NewRecorder()
+	mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteHTTPCtx(r.Context(), w, err)
+	})).ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), `"msg":"привет"`)
+
+	w = httptest.NewRecorder()
+	mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteHTTPProblemCtx(r.Context(), w, err)
+	})).ServeHTTP(w, r)
+	require.Contains(t, w.Body.String(), `"detail":"привет"`)
+}
+
+func TestErrorTranslateContext(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.Russian, &i18n.Message{
+		ID:    "ErrUnread",
+		One:   "У {{.Name}} {{.PluralCount}} непрочитанное сообщение.",
+		Many:  "У {{.Name}} {{.PluralCount}} непрочитанных сообщений.",
+		Other: "У {{.Name}} {{.PluralCount}} непрочитанных сообщений.",
+	})
+	ru := i18n.NewLocalizer(bundle, "ru")
+	en := i18n.NewLocalizer(bundle, "en")
+
+	e := NewWith(
+		SetID("ErrUnread"),
+		SetMsg("unread messages"),
+		SetLocalizer(ru),
+		AppendContextInfo("Name", "John"),
+		AppendContextInfo("PluralCount", 5),
+		SetPluralCount(5),
+	)
+
+	t.Run("error localizer", func(t *testing.T) {
+		require.Equal(t, "У John 5 непрочитанных сообщений.", DefaultTranslate(e))
+		require.Equal(t, "У John 5 непрочитанных сообщений.", fmt.Sprintf("%+s", e))
+		require.Equal(t, "{Name:John,PluralCount:5} У John 5 непрочитанных сообщений.", e.Error())
+	})
+
+	t.Run("explicit context", func(t *testing.T) {
+		msg, err := Translate(e, nil, &TranslateContext{
+			TemplateData: map[string]interface{}{"Name": "Joe", "PluralCount": 1},
+			PluralCount:  1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "У Joe 1 непрочитанное сообщение.", msg)
+	})
+
+	t.Run("explicit localizer", func(t *testing.T) {
+		msg, err := Translate(e, en, nil)
+		require.NoError(t, err)
+		require.Equal(t, "unread messages", msg)
+
+		msg, err = Translate(e.WithOptions(SetDefaultMessage(&i18n.Message{
+			ID:    "ErrUnread",
+			Other: "{{.Name}} has {{.PluralCount}} unread messages.",
+		})), en, nil)
+		require.NoError(t, err)
+		require.Equal(t, "John has 5 unread messages.", msg)
+	})
+
+	t.Run("template data", func(t *testing.T) {
+		tctx := e.WithOptions(SetTemplateData(map[string]interface{}{"Name": "Jane"})).TranslateContext()
+		require.Equal(t, map[string]interface{}{"Name": "Jane"}, tctx.TemplateData)
+		require.Equal(t, map[string]interface{}{"Name": "John", "PluralCount": 5}, e.TranslateContext().TemplateData)
+	})
+}
+
+func TestTranslateMultierror(t *testing.T) {
+	ru := i18n.NewLocalizer(testBundle(), "ru")
+	e1 := NotFoundErrWith(SetID("ErrHello"), SetMsg("hello"))
+	e2 := New("plain")
+
+	err := Combine(e1, fmt.Errorf("call: %w", e1), e2)
+
+	msg, terr := Translate(err, ru, nil)
+	require.NoError(t, terr)
+	require.Equal(t, "the following errors occurred:\n"+
+		"\t#1 (NotFound) привет\n"+
+		"\t#2 call: (NotFound) привет\n"+
+		"\t#3 plain\n", msg)
+
+	msg, terr = Translate(fmt.Errorf("call: %w", e1), ru, nil)
+	require.NoError(t, terr)
+	require.Equal(t, "call: (NotFound) привет", msg)
+
+	msg, terr = Translate(fmt.Errorf("call: %w", origerrors.New("std")), ru, nil)
+	require.ErrorIs(t, terr, ErrNotError)
+	require.Equal(t, "call: std", msg)
+
+	msg, terr = Translate(err, nil, nil)
+	require.ErrorIs(t, terr, ErrNoLocalizer)
+	require.Equal(t, err.Error(), msg)
+
+	data, _ := (&MarshalJSON{Localizer: ru}).Marshal(Wrap(e2, e1))
+	require.Contains(t, string(data), `"msg":"привет"`)
+
+	require.Equal(t,
+		[]string{"привет", "call: (NotFound) привет", "plain", "привет"},
+		TranslateAll(Combine(err, CombineTree("op", e1)), ru),
+	)
+
+	DefaultLocalizer = ru
+	defer func() {
+		DefaultLocalizer = nil
+	}()
+	require.Contains(t, fmt.Sprintf("%+s", err), "(NotFound) привет")
+	require.Contains(t, fmt.Sprintf("%s", err), "(NotFound) hello")
+}
+
+func TestTranslateChainContext(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.Russian, &i18n.Message{ID: "ErrGreet", Other: "привет, {{.Name}}"})
+	ru := i18n.NewLocalizer(bundle, "ru")
+
+	e := NewWith(SetID("ErrGreet"), SetMsg("hi"))
+	tctx := &TranslateContext{TemplateData: map[string]interface{}{"Name": "Jane"}}
+
+	msg, err := Translate(Combine(e, fmt.Errorf("call: %w", e)), ru, tctx)
+	require.NoError(t, err)
+	require.Equal(t, "the following errors occurred:\n"+
+		"\t#1 привет, Jane\n"+
+		"\t#2 call: привет, Jane\n", msg)
+
+	msg, err = Translate(fmt.Errorf("call: %w", e), ru, tctx)
+	require.NoError(t, err)
+	require.Equal(t, "call: привет, Jane", msg)
+}
+
+func TestErrorDumpOmitsLocalization(t *testing.T) {
+	e := NewWith(
+		SetID("ErrUnread"), SetMsg("unread"),
+		SetLocalizer(i18n.NewLocalizer(testBundle(), "ru")),
+		SetTemplateData(map[string]interface{}{"Name": "secret-name"}),
+		SetPluralCount(5),
+	)
+
+	// поля дампа без вызова метода Error()
+	dump := (&spew.ConfigState{Indent: " ", DisableMethods: true}).Sdump(e.dumpable())
+	require.Contains(t, dump, "ErrUnread")
+	require.NotContains(t, dump, "secret-name")
+	require.NotContains(t, dump, "Bundle")
+	require.NotContains(t, dump, "(int) 5")
+
+	require.Equal(t, "unread", e.dumpable().Error())
+	require.Equal(t, 5, e.pluralCount)
+	require.NotNil(t, e.localizer)
+}
@@ -0,0 +1,193 @@
+package errors
+
+import (
+	"sort"
+	"strings"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// FieldViolation нарушение правила валидации поля.
+type FieldViolation struct {
+	// Field путь к полю, например "user.emails[0]".
+	Field string
+	// Code код нарушенного правила, например "required".
+	Code string
+	// Message сообщение о нарушении.
+	Message string
+	// TranslationID ID сообщения перевода.
+	TranslationID string
+	// Params данные для шаблона перевода.
+	Params map[string]interface{}
+}
+
+// Error вернет строковое представление нарушения в виде "field: message".
+func (v FieldViolation) Error() string {
+	if v.Field == "" {
+		return v.Message
+	}
+	return v.Field + ": " + v.Message
+}
+
+// Translate вернет перевод сообщения о нарушении.
+// Для перевода используется TranslationID и Params, локализатор выбирается как в Translate.
+// Если перевод не выполнен, вернется Message.
+func (v FieldViolation) Translate(l Localizer) (string, error) {
+	if v.TranslationID == "" {
+		return v.Message, nil
+	}
+
+	loc := localizerOf(l)
+	if loc == nil {
+		return v.Message, ErrNoLocalizer
+	}
+
+	msg, err := loc.Localize(&i18n.LocalizeConfig{
+		MessageID:    v.TranslationID,
+		TemplateData: v.Params,
+	})
+	if err != nil {
+		return v.Message, nil
+	}
+	return msg, nil
+}
+
+// FieldViolations вернет нарушения валидации полей.
+func (e *Error) FieldViolations() []FieldViolation {
+	if e == nil {
+		return nil
+	}
+	return e.violations
+}
+
+// Field violations
+
+// AppendFieldViolations, ...FieldViolation. Добавит нарушения валидации полей.
+func AppendFieldViolations(v ...FieldViolation) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		// копия, чтобы не изменить срез исходной ошибки (см. WithOptions)
+		e.violations = append(e.violations[:len(e.violations):len(e.violations)], v...)
+	}
+}
+
+// SetFieldViolations, []FieldViolation. Установит нарушения валидации полей.
+func SetFieldViolations(v []FieldViolation) Options {
+	return func(e *Error) {
+		if e == nil {
+			return
+		}
+		e.violations = v
+	}
+}
+
+// FieldViolationsFromMap вернет нарушения валидации из map[поле]ошибка, упорядоченные по полю.
+// Для *Error сообщением будет Msg(), а ID перевода -- ID();
+// если *Error содержит нарушения валидации, они будут добавлены с префиксом поля
+// (например, "address" + "city" -> "address.city").
+// Ошибки nil пропускаются.
+func FieldViolationsFromMap(errs map[string]error) []FieldViolation {
+	fields := make([]string, 0, len(errs))
+	for field, err := range errs {
+		if err != nil {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	violations := make([]FieldViolation, 0, len(fields))
+	for _, field := range fields {
+		violations = append(violations, fieldViolationsOf(field, errs[field])...)
+	}
+	return violations
+}
+
+// ValidationFailedID ID ошибок, созданных ValidationFieldsErr и ValidationMapErr.
+const ValidationFailedID = "ErrValidationFailed"
+
+// ValidationFieldsErr конструктор *Error c типом Validation, ID ValidationFailedID
+// и нарушениями валидации полей.
+// * violations []FieldViolation -- нарушения валидации полей.
+// * ops ...Options -- параметризация через функции-парметры.
+// Если нарушений нет, вернется nil (error, а не типизированный nil *Error,
+// поэтому результат можно сравнивать с nil).
+func ValidationFieldsErr(violations []FieldViolation, ops ...Options) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	op := make([]Options, 0, len(ops)+3)
+	op = append(op, SetID(ValidationFailedID), SetMsg("validation failed"), SetFieldViolations(violations))
+	op = append(op, ops...)
+	return ValidationErrWith(op...)
+}
+
+// ValidationMapErr конструктор *Error c типом Validation
+// и нарушениями валидации полей из map[поле]ошибка (см. FieldViolationsFromMap).
+// Если ошибок нет, вернется nil (как и ValidationFieldsErr).
+func ValidationMapErr(errs map[string]error, ops ...Options) error {
+	return ValidationFieldsErr(FieldViolationsFromMap(errs), ops...)
+}
+
+// CollectFieldViolations вернет нарушения валидации всех *Error дерева ошибок err.
+func CollectFieldViolations(err error) []FieldViolation {
+	var violations []FieldViolation
+	Walk(err, func(e error, _ int) bool {
+		if ee, ok := e.(*Error); ok { //nolint:errorlint
+			violations = append(violations, ee.FieldViolations()...)
+		}
+		return false
+	})
+	return violations
+}
+
+// TranslateFieldViolations вернет нарушения валидации дерева ошибок err
+// с переведенными сообщениями (см. FieldViolation.Translate).
+func TranslateFieldViolations(err error, l Localizer) []FieldViolation {
+	violations := CollectFieldViolations(err)
+	for i := range violations {
+		violations[i].Message, _ = violations[i].Translate(l)
+	}
+	return violations
+}
+
+func fieldViolationsOf(field string, err error) []FieldViolation {
+	switch t := err.(type) { //nolint:errorlint
+	case FieldViolation:
+		if t.Field == "" {
+			t.Field = field
+		}
+		return []FieldViolation{t}
+
+	case *Error:
+		if nested := t.FieldViolations(); len(nested) > 0 {
+			out := make([]FieldViolation, len(nested))
+			for i, v := range nested {
+				v.Field = joinFieldPath(field, v.Field)
+				out[i] = v
+			}
+			return out
+		}
+		return []FieldViolation{{
+			Field:         field,
+			Message:       t.Msg(),
+			TranslationID: t.ID(),
+		}}
+	}
+
+	return []FieldViolation{{Field: field, Message: err.Error()}}
+}
+
+func joinFieldPath(parent, field string) string {
+	switch {
+	case parent == "":
+		return field
+	case field == "":
+		return parent
+	case strings.HasPrefix(field, "["):
+		return parent + field
+	}
+	return parent + "." + field
+}
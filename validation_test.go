@@ -0,0 +1,138 @@
+package errors
+
+import (
+	"encoding/json"
+	origerrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	i18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestValidationMapErr(t *testing.T) {
+	// NoError не пропустит типизированный nil.
+	require.NoError(t, ValidationMapErr(map[string]error{"name": nil}))
+	require.NoError(t, ValidationFieldsErr(nil))
+
+	err := ValidationMapErr(map[string]error{
+		"name":  origerrors.New("is required"),
+		"email": NewWith(SetID("ErrBadEmail"), SetMsg("bad email")),
+		"address": ValidationFieldsErr([]FieldViolation{
+			{Field: "city", Code: "required", Message: "is required"},
+			{Field: "[0]", Message: "bad line"},
+		}),
+		"age": FieldViolation{Code: "min", Message: "too small"},
+	})
+
+	e, ok := err.(*Error) //nolint:errorlint
+	require.True(t, ok)
+	require.Equal(t, Validation, e.ErrorType())
+	require.Equal(t, ValidationFailedID, e.ID())
+	require.Equal(t, []FieldViolation{
+		{Field: "address.city", Code: "required", Message: "is required"},
+		{Field: "address[0]", Message: "bad line"},
+		{Field: "age", Code: "min", Message: "too small"},
+		{Field: "email", Message: "bad email", TranslationID: "ErrBadEmail"},
+		{Field: "name", Message: "is required"},
+	}, e.FieldViolations())
+}
+
+func TestAppendFieldViolations(t *testing.T) {
+	base := ValidationErrWith(AppendFieldViolations(FieldViolation{Field: "a"}))
+	e1 := base.WithOptions(AppendFieldViolations(FieldViolation{Field: "b"}))
+	e2 := base.WithOptions(AppendFieldViolations(FieldViolation{Field: "c"}))
+
+	require.Len(t, base.FieldViolations(), 1)
+	require.Equal(t, "b", e1.FieldViolations()[1].Field)
+	require.Equal(t, "c", e2.FieldViolations()[1].Field)
+}
+
+func TestTranslateFieldViolations(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.Russian, &i18n.Message{
+		ID:    "ErrMin",
+		Other: "не меньше {{.Min}}",
+	})
+	l := i18n.NewLocalizer(bundle, "ru")
+
+	err := Wrap(
+		ValidationFieldsErr([]FieldViolation{
+			{Field: "age", Message: "too small", TranslationID: "ErrMin", Params: map[string]interface{}{"Min": 18}},
+			{Field: "name", Message: "is required", TranslationID: "ErrUnknown"},
+		}),
+		origerrors.New("std"),
+	)
+
+	violations := TranslateFieldViolations(err, l)
+	require.Len(t, violations, 2)
+	require.Equal(t, "не меньше 18", violations[0].Message)
+	require.Equal(t, "is required", violations[1].Message)
+}
+
+func TestMarshalJSONInvalidParams(t *testing.T) {
+	err := ValidationFieldsErr([]FieldViolation{
+		{Field: "name", Code: "required", Message: `is "required"`},
+	})
+
+	data, _ := (&MarshalJSON{}).Marshal(err)
+	var body struct {
+		InvalidParams []map[string]string `json:"invalid_params"`
+	}
+	require.NoError(t, json.Unmarshal(data, &body))
+	require.Equal(t, []map[string]string{
+		{"name": "name", "code": "required", "reason": `is "required"`},
+	}, body.InvalidParams)
+}
+
+func TestWriteHTTPProblem(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteHTTPProblem(w, ValidationFieldsErr(
+		[]FieldViolation{{Field: "name", Message: "is required"}},
+		SetID("ErrInvalid"),
+	))
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Equal(t, ContentTypeProblemJSON, w.Header().Get("Content-Type"))
+	require.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "validation failed",
+		"id": "ErrInvalid",
+		"error_type": "Validation",
+		"invalid_params": [{"name": "name", "reason": "is required"}]
+	}`, w.Body.String())
+}
+
+func TestMarshalInvalidParamsLocalizer(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.Russian, &i18n.Message{
+		ID:    "ErrMin",
+		Other: "не меньше {{.Min}}",
+	})
+	l := i18n.NewLocalizer(bundle, "ru")
+
+	err := ValidationFieldsErr([]FieldViolation{
+		{Field: "age", Message: "too small", TranslationID: "ErrMin", Params: map[string]interface{}{"Min": 18}},
+	})
+	want := []map[string]string{{"name": "age", "reason": "не меньше 18"}}
+
+	var body struct {
+		InvalidParams []map[string]string `json:"invalid_params"`
+	}
+
+	data, _ := (&MarshalJSON{Localizer: l}).Marshal(err)
+	require.NoError(t, json.Unmarshal(data, &body))
+	require.Equal(t, want, body.InvalidParams)
+
+	data, _ = MarshalProblemJSON{Localizer: l}.Marshal(err)
+	require.NoError(t, json.Unmarshal(data, &body))
+	require.Equal(t, want, body.InvalidParams)
+
+	data, _ = (&MarshalJSON{}).Marshal(err)
+	require.NoError(t, json.Unmarshal(data, &body))
+	require.Equal(t, "too small", body.InvalidParams[0]["reason"])
+}
@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"reflect"
+)
+
+// Walk обойдет в глубину дерево ошибок err, вызвав fn для каждого узла.
+// * depth -- глубина узла, для err равна 0.
+// Если fn вернет true, обход будет прекращен.
+//
+// Дочерними узлами считаются:
+// * элементы Multierror и ошибок, полученных с помощью errors.Join;
+// * WrappedErrors() []error (github.com/hashicorp/go-multierror);
+// * Errors() []error (go.uber.org/multierr);
+// * Unwrap() []error и Unwrap() error (в т.ч. fmt.Errorf("%w")).
+// Узел, уже находящийся на пути от корня (цикл), повторно не обходится;
+// одна и та же ошибка в разных ветвях дерева обходится каждый раз.
+func Walk(err error, fn func(e error, depth int) (stop bool)) {
+	if err == nil {
+		return
+	}
+	w := walker{fn: fn}
+	w.walk(err, 0)
+}
+
+type walker struct {
+	fn func(error, int) bool
+	// post вызывается для списка ошибок после обхода его элементов (см. Find)
+	post func(error, int) bool
+	// path узлы на пути от корня до текущего узла
+	path map[error]struct{}
+}
+
+func (w *walker) walk(err error, depth int) bool {
+	if err == nil {
+		return false
+	}
+
+	if reflect.TypeOf(err).Comparable() {
+		if _, ok := w.path[err]; ok {
+			return false
+		}
+		if w.path == nil {
+			w.path = make(map[error]struct{})
+		}
+		w.path[err] = struct{}{}
+		defer delete(w.path, err)
+	}
+
+	if w.fn(err, depth) {
+		return true
+	}
+
+	if children, ok := listErrors(err); ok {
+		for _, e := range children {
+			if w.walk(e, depth+1) {
+				return true
+			}
+		}
+		return w.post != nil && w.post(err, depth)
+	}
+
+	if u, ok := err.(interface{ Unwrap() error }); ok { //nolint:errorlint
+		return w.walk(u.Unwrap(), depth+1)
+	}
+
+	return false
+}
+
+// listErrors вернет элементы err, если err является списком ошибок.
+func listErrors(err error) ([]error, bool) {
+	if nested, ok := nestedErrors(err); ok {
+		return nested, true
+	}
+
+	switch t := err.(type) { //nolint:errorlint
+	case interface{ WrappedErrors() []error }:
+		return t.WrappedErrors(), true
+	case interface{ Errors() []error }:
+		return t.Errors(), true
+	case interface{ Unwrap() []error }:
+		return t.Unwrap(), true
+	}
+
+	return nil, false
+}
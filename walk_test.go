@@ -0,0 +1,141 @@
+package errors
+
+import (
+	origerrors "errors"
+	"fmt"
+	"testing"
+
+	hashmultierr "github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/require"
+	ubermulierr "go.uber.org/multierr"
+)
+
+type loopErr struct {
+	next error
+}
+
+func (e *loopErr) Error() string { return "loop" }
+
+func (e *loopErr) Unwrap() error { return e.next }
+
+func TestWalk(t *testing.T) {
+	e1 := New("one")
+	e2 := origerrors.New("two")
+	e3 := NewWith(SetMsg("three"), SetID("three"))
+
+	wrapped := fmt.Errorf("wrapped: %w", e3)
+	err := Combine(e1, fmt.Errorf("ctx: %w", Combine(e2, wrapped)))
+
+	type node struct {
+		err   error
+		depth int
+	}
+	var got []node
+	Walk(err, func(e error, depth int) bool {
+		got = append(got, node{e, depth})
+		return false
+	})
+
+	require.Len(t, got, 7)
+	require.Equal(t, node{err, 0}, got[0])
+	require.Equal(t, node{e1, 1}, got[1])
+	require.Equal(t, node{e2, 3}, got[4])
+	require.Equal(t, node{wrapped, 3}, got[5])
+	require.Equal(t, node{e3, 4}, got[6])
+
+	var count int
+	Walk(err, func(e error, _ int) bool {
+		count++
+		return e == e1
+	})
+	require.Equal(t, 2, count)
+}
+
+func TestWalkCycle(t *testing.T) {
+	l1 := &loopErr{}
+	l2 := &loopErr{next: l1}
+	l1.next = l2
+
+	var count int
+	Walk(l1, func(error, int) bool {
+		count++
+		return false
+	})
+	require.Equal(t, 2, count)
+}
+
+func TestWalkRepeated(t *testing.T) {
+	e1 := New("one")
+	shared := fmt.Errorf("ctx: %w", e1)
+
+	var got []error
+	Walk(Combine(e1, e1, shared, shared), func(e error, _ int) bool {
+		got = append(got, e)
+		return false
+	})
+	require.Len(t, got, 7)
+	require.Equal(t, []error{e1, e1, shared, e1, shared, e1}, got[1:])
+
+	var count int
+	Walk(Combine(e1, e1), func(error, int) bool {
+		count++
+		return false
+	})
+	require.Equal(t, 3, count)
+}
+
+func TestFindRoot(t *testing.T) {
+	merr := Combine(New("one"), New("two"))
+	require.True(t, ContainsByErr(merr, merr))
+	require.Equal(t, merr, FindByErr(merr, merr))
+
+	// вложенный список проверяется после своих элементов, но до следующих ошибок
+	node := CombineTree("op", New("three"))
+	last := New("four")
+	require.Equal(t, node, FindByErr(Combine(New("one"), node), node))
+	isList := func(e error) bool {
+		_, ok := e.(Multierror) //nolint:errorlint
+		return ok
+	}
+	require.Equal(t, node, Find(Combine(node, last), isList))
+	require.Equal(t, last, Find(Combine(last, node), func(e error) bool { return e == last || isList(e) }))
+}
+
+func TestFindDeep(t *testing.T) {
+	e1 := NewWith(SetMsg("one"), SetID("one"))
+	e2 := origerrors.New("two")
+	e3 := NewWith(SetMsg("three"), SetID("three"))
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "fmt wrap",
+			err:  fmt.Errorf("ctx: %w", Combine(e2, e3)),
+		},
+		{
+			name: "hashicorp",
+			err:  hashmultierr.Append(e2, fmt.Errorf("ctx: %w", e3)),
+		},
+		{
+			name: "uber",
+			err:  ubermulierr.Combine(e1, e2, e3),
+		},
+		{
+			name: "nested",
+			err:  Wrap(e1, hashmultierr.Append(e2, ubermulierr.Combine(e1, e3))),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, e3, FindByID(tt.err, "three"))
+			require.True(t, ContainsByID(tt.err, "three"))
+			require.Equal(t, e2, FindByErr(tt.err, e2))
+			require.True(t, ContainsByErr(tt.err, e2))
+			require.False(t, ContainsByErr(tt.err, origerrors.New("two")))
+			require.Nil(t, FindByID(tt.err, "unknown"))
+		})
+	}
+}